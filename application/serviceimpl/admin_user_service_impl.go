@@ -0,0 +1,348 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errSelfModification = errors.New("administrators cannot perform this action on their own account")
+
+type AdminUserServiceImpl struct {
+	userRepo     repositories.UserRepository
+	roleRepo     repositories.RoleRepository
+	patRepo      repositories.PersonalAccessTokenRepository
+	rbacService  services.RBACService
+	tokenService services.TokenService
+	userService  services.UserService
+	auditService services.AuditService
+	syncService  *SyncService
+}
+
+func NewAdminUserService(
+	userRepo repositories.UserRepository,
+	roleRepo repositories.RoleRepository,
+	patRepo repositories.PersonalAccessTokenRepository,
+	rbacService services.RBACService,
+	tokenService services.TokenService,
+	userService services.UserService,
+	auditService services.AuditService,
+	syncService *SyncService,
+) services.AdminUserService {
+	return &AdminUserServiceImpl{
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		patRepo:      patRepo,
+		rbacService:  rbacService,
+		tokenService: tokenService,
+		userService:  userService,
+		auditService: auditService,
+		syncService:  syncService,
+	}
+}
+
+func (s *AdminUserServiceImpl) GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+func (s *AdminUserServiceImpl) UpdateUser(ctx context.Context, actorID, userID uuid.UUID, req *dto.AdminUpdateUserRequest) (*models.User, error) {
+	user, err := s.getManagedUser(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	identityChanged := false
+	wasVerified := user.EmailVerified
+
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if !strings.EqualFold(email, user.Email) {
+			if existing, _ := s.userRepo.GetByEmail(ctx, email); existing != nil {
+				return nil, errors.New("email already exists")
+			}
+			fields["email"] = email
+			// A new address has not been verified by its owner
+			fields["email_verified"] = false
+			user.Email = email
+			user.EmailVerified = false
+			identityChanged = true
+		}
+	}
+	if req.Username != nil && *req.Username != user.Username {
+		if existing, _ := s.userRepo.GetByUsername(ctx, *req.Username); existing != nil {
+			return nil, errors.New("username already exists")
+		}
+		fields["username"] = *req.Username
+		user.Username = *req.Username
+		identityChanged = true
+	}
+	if req.DisplayName != nil {
+		fields["display_name"] = *req.DisplayName
+		user.DisplayName = *req.DisplayName
+	}
+	if req.Avatar != nil {
+		fields["avatar"] = *req.Avatar
+		user.Avatar = *req.Avatar
+	}
+	if req.EmailVerified != nil {
+		fields["email_verified"] = *req.EmailVerified
+		user.EmailVerified = *req.EmailVerified
+	}
+
+	if len(fields) == 0 {
+		return user, nil
+	}
+
+	changed := make([]string, 0, len(fields))
+	for field := range fields {
+		changed = append(changed, field)
+	}
+	sort.Strings(changed)
+
+	user.UpdatedAt = time.Now()
+	fields["updated_at"] = user.UpdatedAt
+
+	// Downstream services only hold identity data (id, email, username)
+	var events []*models.OutboxEvent
+	if identityChanged {
+		events = append(events, s.syncService.UserEvent(ctx, user, "updated"))
+	}
+	if user.EmailVerified && !wasVerified {
+		events = append(events, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+			Action:  "email_verified",
+			ActorID: actorID.String(),
+		}))
+	}
+	if err := s.userRepo.UpdateFields(ctx, userID, fields, events...); err != nil {
+		return nil, err
+	}
+
+	s.logAdminAction(ctx, "admin_user_update", actorID, userID, map[string]interface{}{
+		"fields": changed,
+	})
+
+	return user, nil
+}
+
+func (s *AdminUserServiceImpl) DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return errSelfModification
+	}
+
+	user, err := s.getManagedUser(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, userID, s.syncService.UserEvent(ctx, user, "deleted")); err != nil {
+		return err
+	}
+
+	s.revokeSessions(ctx, userID)
+	s.logAdminAction(ctx, "admin_user_delete", actorID, userID, nil)
+
+	return nil
+}
+
+func (s *AdminUserServiceImpl) ActivateUser(ctx context.Context, actorID, userID uuid.UUID) (*models.User, error) {
+	return s.setActive(ctx, actorID, userID, true, "")
+}
+
+func (s *AdminUserServiceImpl) DeactivateUser(ctx context.Context, actorID, userID uuid.UUID, reason string) (*models.User, error) {
+	return s.setActive(ctx, actorID, userID, false, reason)
+}
+
+func (s *AdminUserServiceImpl) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*models.User, error) {
+	if actorID == userID {
+		return nil, errSelfModification
+	}
+
+	user, err := s.getManagedUser(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName := normalizeRoleName(role)
+	target, err := s.roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+
+	if user.Role == roleName {
+		return user, nil
+	}
+
+	// The primary role grants permissions like an assigned role, so the same escalation rule applies
+	if err := s.rbacService.CheckGrantable(ctx, actorID, target); err != nil {
+		return nil, err
+	}
+
+	previousRole := user.Role
+	user.Role = roleName
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateFields(ctx, userID, map[string]interface{}{
+		"role":       roleName,
+		"updated_at": user.UpdatedAt,
+	}, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action:       "role_changed",
+		Role:         roleName,
+		PreviousRole: previousRole,
+		ActorID:      actorID.String(),
+	})); err != nil {
+		return nil, err
+	}
+
+	// Existing tokens carry the old role's permissions
+	s.revokeSessions(ctx, userID)
+	s.logAdminAction(ctx, "admin_user_role_change", actorID, userID, map[string]interface{}{
+		"previous_role": previousRole,
+		"role":          roleName,
+	})
+
+	return user, nil
+}
+
+func (s *AdminUserServiceImpl) ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.getManagedUser(ctx, actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Clearing the hash blocks password login until the user sets a new one
+	if err := s.userRepo.UpdateFields(ctx, userID, map[string]interface{}{
+		"password":   nil,
+		"updated_at": time.Now(),
+	}, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action:  "password_changed",
+		Reason:  services.PasswordCleared,
+		ActorID: actorID.String(),
+	})); err != nil {
+		return err
+	}
+
+	s.revokeSessions(ctx, userID)
+
+	// Personal access tokens may have been created by whoever knew the old password
+	if err := s.patRepo.RevokeAllForUser(ctx, userID, time.Now()); err != nil {
+		return err
+	}
+
+	s.logAdminAction(ctx, "admin_user_force_password_reset", actorID, userID, nil)
+
+	return s.userService.RequestPasswordReset(ctx, user.Email)
+}
+
+// ==================== Helper Methods ====================
+
+func (s *AdminUserServiceImpl) setActive(ctx context.Context, actorID, userID uuid.UUID, active bool, reason string) (*models.User, error) {
+	if actorID == userID {
+		return nil, errSelfModification
+	}
+
+	user, err := s.getManagedUser(ctx, actorID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsActive == active {
+		return user, nil
+	}
+
+	action := "activated"
+	if !active {
+		action = "deactivated"
+	}
+
+	user.IsActive = active
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateFields(ctx, userID, map[string]interface{}{
+		"is_active":  active,
+		"updated_at": user.UpdatedAt,
+	}, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action:  action,
+		ActorID: actorID.String(),
+	})); err != nil {
+		return nil, err
+	}
+
+	if !active {
+		s.revokeSessions(ctx, userID)
+	}
+
+	s.logAdminAction(ctx, "admin_user_"+action, actorID, userID, map[string]interface{}{
+		"reason": reason,
+	})
+
+	return user, nil
+}
+
+// revokeSessions ends all JWT sessions of the user; failures are logged because
+// the account change itself has already been applied
+// getManagedUser loads a user the actor may modify: one holding no permission the actor lacks,
+// so that administrators cannot be taken over or locked out by less privileged staff
+func (s *AdminUserServiceImpl) getManagedUser(ctx context.Context, actorID, userID uuid.UUID) (*models.User, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rbacService.CheckManageable(ctx, actorID, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AdminUserServiceImpl) revokeSessions(ctx context.Context, userID uuid.UUID) {
+	if err := s.tokenService.RevokeAllForUser(ctx, userID); err != nil {
+		logger.GetLogger().Warn("Failed to revoke user tokens", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"user_id":    userID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+// adminAuditEvents maps logged admin actions to audit event types
+var adminAuditEvents = map[string]string{
+	"admin_user_update":               models.AuditUserUpdated,
+	"admin_user_delete":               models.AuditUserDeleted,
+	"admin_user_activated":            models.AuditUserActivated,
+	"admin_user_deactivated":          models.AuditUserDeactivated,
+	"admin_user_role_change":          models.AuditUserRoleChanged,
+	"admin_user_force_password_reset": models.AuditUserPasswordReset,
+}
+
+// logAdminAction writes the action to the application log and the audit log
+func (s *AdminUserServiceImpl) logAdminAction(ctx context.Context, action string, actorID, userID uuid.UUID, extra map[string]interface{}) {
+	fields := map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     action,
+		"actor_id":   actorID.String(),
+		"user_id":    userID.String(),
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	logger.GetLogger().Info("Admin user action", fields)
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: adminAuditEvents[action],
+		ActorID:   &actorID,
+		SubjectID: &userID,
+		Metadata:  extra,
+	})
+}
@@ -0,0 +1,245 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditServiceImpl struct {
+	auditRepo  repositories.AuditRepository
+	signingKey ed25519.PrivateKey
+}
+
+func NewAuditService(
+	auditRepo repositories.AuditRepository,
+	cfg *config.Config,
+) services.AuditService {
+	s := &AuditServiceImpl{
+		auditRepo: auditRepo,
+	}
+
+	if cfg.Audit.SigningKey != "" {
+		key, err := utils.ParseEd25519Seed(cfg.Audit.SigningKey)
+		if err != nil {
+			logger.GetLogger().Error("Invalid audit signing key, checkpoints disabled", map[string]interface{}{
+				"action": "audit_signing_key",
+				"error":  err.Error(),
+			})
+		} else {
+			s.signingKey = key
+		}
+	}
+
+	return s
+}
+
+func (s *AuditServiceImpl) Record(ctx context.Context, event *models.AuditEvent) {
+	if event.Outcome == "" {
+		event.Outcome = models.AuditOutcomeSuccess
+	}
+	if event.ActorID == nil {
+		if actorID := contextutil.GetActorID(ctx); actorID != uuid.Nil {
+			event.ActorID = &actorID
+		}
+	}
+	if event.ImpersonatorID == nil {
+		if impersonatorID := contextutil.GetImpersonatorID(ctx); impersonatorID != uuid.Nil {
+			event.ImpersonatorID = &impersonatorID
+		}
+	}
+	if event.IPAddress == "" {
+		event.IPAddress = contextutil.GetClientIP(ctx)
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = contextutil.GetUserAgent(ctx)
+	}
+	event.UserAgent = truncate(event.UserAgent, 500)
+	if event.RequestID == "" {
+		event.RequestID = contextutil.GetRequestID(ctx)
+	}
+	// The chain hash covers created_at, so keep only the precision Postgres stores
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	// Detached from the request so a client disconnect does not drop the record
+	if err := s.auditRepo.Create(context.WithoutCancel(ctx), event); err != nil {
+		logger.GetLogger().Error("Failed to record audit event", map[string]interface{}{
+			"request_id": event.RequestID,
+			"action":     "audit_record",
+			"event_type": event.EventType,
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (s *AuditServiceImpl) Search(ctx context.Context, query *dto.AuditEventQuery) ([]*models.AuditEvent, string, error) {
+	filter, err := auditFilterFromQuery(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra row to know whether another page follows
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	events, err := s.auditRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(events) > limit {
+		events = events[:limit]
+		nextCursor = encodeAuditCursor(events[len(events)-1])
+	}
+
+	return events, nextCursor, nil
+}
+
+func (s *AuditServiceImpl) RecentActivity(ctx context.Context, userID uuid.UUID, limit int) ([]*models.AuditEvent, error) {
+	if limit <= 0 {
+		limit = dto.DefaultActivityLimit
+	}
+
+	return s.auditRepo.Search(ctx, &repositories.AuditFilter{
+		UserID: &userID,
+		Limit:  limit,
+	})
+}
+
+func (s *AuditServiceImpl) WriteCheckpoint(ctx context.Context) (*models.AuditCheckpoint, error) {
+	if s.signingKey == nil {
+		return nil, nil
+	}
+
+	head, err := s.auditRepo.GetChainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, nil
+	}
+
+	latest, err := s.auditRepo.GetLatestCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.Sequence >= head.Sequence {
+		return nil, nil
+	}
+
+	checkpoint := &models.AuditCheckpoint{
+		ID:        uuid.New(),
+		Sequence:  head.Sequence,
+		EventID:   head.ID,
+		Hash:      head.Hash,
+		KeyID:     utils.KeyID(s.signingKey.Public().(ed25519.PublicKey)),
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	checkpoint.Signature = base64.StdEncoding.EncodeToString(
+		ed25519.Sign(s.signingKey, checkpoint.SigningPayload()),
+	)
+
+	if err := s.auditRepo.CreateCheckpoint(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().Info("Audit checkpoint written", map[string]interface{}{
+		"action":   "audit_checkpoint",
+		"sequence": checkpoint.Sequence,
+		"hash":     checkpoint.Hash,
+		"key_id":   checkpoint.KeyID,
+	})
+
+	return checkpoint, nil
+}
+
+// auditCursor is the opaque keyset position returned as nextCursor
+type auditCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func auditFilterFromQuery(query *dto.AuditEventQuery) (*repositories.AuditFilter, error) {
+	filter := &repositories.AuditFilter{
+		EventType: query.Type,
+		Outcome:   query.Outcome,
+		Limit:     query.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = dto.DefaultAuditPageSize
+	}
+	if filter.Limit > dto.MaxAuditPageSize {
+		filter.Limit = dto.MaxAuditPageSize
+	}
+
+	ids := []struct {
+		value  string
+		target **uuid.UUID
+	}{
+		{query.ActorID, &filter.ActorID},
+		{query.SubjectID, &filter.SubjectID},
+	}
+	for _, id := range ids {
+		if id.value == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(id.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %s", id.value)
+		}
+		*id.target = &parsed
+	}
+
+	ranges := []struct {
+		value  string
+		target **time.Time
+	}{
+		{query.From, &filter.From},
+		{query.To, &filter.To},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, r.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %s", r.value)
+		}
+		*r.target = &t
+	}
+
+	if query.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		var cursor auditCursor
+		if err := json.Unmarshal(raw, &cursor); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		filter.BeforeCreatedAt = &cursor.CreatedAt
+		filter.BeforeID = &cursor.ID
+	}
+
+	return filter, nil
+}
+
+func encodeAuditCursor(last *models.AuditEvent) string {
+	raw, _ := json.Marshal(auditCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
@@ -0,0 +1,161 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/metrics"
+
+	"github.com/google/uuid"
+)
+
+var errDeadLetterNotFound = errors.New("dead-letter event not found")
+
+type DeadLetterServiceImpl struct {
+	deadLetterRepo repositories.DeadLetterRepository
+	auditService   services.AuditService
+}
+
+func NewDeadLetterService(
+	deadLetterRepo repositories.DeadLetterRepository,
+	auditService services.AuditService,
+) services.DeadLetterService {
+	return &DeadLetterServiceImpl{
+		deadLetterRepo: deadLetterRepo,
+		auditService:   auditService,
+	}
+}
+
+func (s *DeadLetterServiceImpl) Add(ctx context.Context, event *models.DeadLetterEvent) error {
+	if len(event.LastError) > 500 {
+		event.LastError = event.LastError[:500]
+	}
+	if err := s.deadLetterRepo.Create(ctx, event); err != nil {
+		return err
+	}
+
+	metrics.DeadLetterEventsTotal.WithLabelValues(event.Topic, event.Source).Inc()
+	size := s.reportSize(ctx)
+
+	// Every new entry means a downstream service missed a change; alert on it
+	logger.GetLogger().Error("Event moved to dead-letter queue", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "dead_letter",
+		"alert":      "dead_letter_queue_grew",
+		"event_id":   event.ID.String(),
+		"user_id":    event.AggregateID.String(),
+		"topic":      event.Topic,
+		"source":     event.Source,
+		"attempts":   event.Attempts,
+		"error":      event.LastError,
+		"queue_size": size,
+	})
+	return nil
+}
+
+func (s *DeadLetterServiceImpl) List(ctx context.Context, query *dto.DeadLetterQuery) ([]*models.DeadLetterEvent, *dto.PaginationMeta, error) {
+	filter := &repositories.DeadLetterFilter{
+		Topic:  query.Topic,
+		Source: query.Source,
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = dto.DefaultDeadLetterPageSize
+	}
+	if filter.Limit > dto.MaxDeadLetterPageSize {
+		filter.Limit = dto.MaxDeadLetterPageSize
+	}
+	if query.UserID != "" {
+		userID, err := uuid.Parse(query.UserID)
+		if err != nil {
+			return nil, nil, errors.New("invalid user ID")
+		}
+		filter.AggregateID = &userID
+	}
+
+	events, total, err := s.deadLetterRepo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return events, &dto.PaginationMeta{
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+func (s *DeadLetterServiceImpl) Get(ctx context.Context, id uuid.UUID) (*models.DeadLetterEvent, error) {
+	event, err := s.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errDeadLetterNotFound
+	}
+	return event, nil
+}
+
+func (s *DeadLetterServiceImpl) Retry(ctx context.Context, actorID, id uuid.UUID) error {
+	event, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deadLetterRepo.Requeue(ctx, id); err != nil {
+		return err
+	}
+	s.reportSize(ctx)
+
+	s.logAction(ctx, models.AuditDeadLetterRetried, actorID, event)
+	return nil
+}
+
+func (s *DeadLetterServiceImpl) Discard(ctx context.Context, actorID, id uuid.UUID) error {
+	event, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deadLetterRepo.Delete(ctx, id); err != nil {
+		return errDeadLetterNotFound
+	}
+	s.reportSize(ctx)
+
+	s.logAction(ctx, models.AuditDeadLetterDiscarded, actorID, event)
+	return nil
+}
+
+// reportSize updates the queue size gauge and returns the size (-1 when it could not be read)
+func (s *DeadLetterServiceImpl) reportSize(ctx context.Context) int64 {
+	size, err := s.deadLetterRepo.Count(ctx)
+	if err != nil {
+		return -1
+	}
+	metrics.DeadLetterQueueSize.Set(float64(size))
+	return size
+}
+
+func (s *DeadLetterServiceImpl) logAction(ctx context.Context, eventType string, actorID uuid.UUID, event *models.DeadLetterEvent) {
+	logger.GetLogger().Info("Dead-letter event handled", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     eventType,
+		"actor_id":   actorID.String(),
+		"event_id":   event.ID.String(),
+		"topic":      event.Topic,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: eventType,
+		ActorID:   &actorID,
+		SubjectID: &event.AggregateID,
+		Metadata: map[string]interface{}{
+			"event_id": event.ID.String(),
+			"topic":    event.Topic,
+			"source":   event.Source,
+		},
+	})
+}
@@ -0,0 +1,214 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"strings"
+	"time"
+)
+
+type DeviceServiceImpl struct {
+	deviceRepo   repositories.KnownDeviceRepository
+	auditService services.AuditService
+	emailSender  services.EmailSender
+	events       *EventEmitter
+	config       *config.Config
+}
+
+func NewDeviceService(
+	deviceRepo repositories.KnownDeviceRepository,
+	auditService services.AuditService,
+	emailSender services.EmailSender,
+	events *EventEmitter,
+	cfg *config.Config,
+) services.DeviceService {
+	return &DeviceServiceImpl{
+		deviceRepo:   deviceRepo,
+		auditService: auditService,
+		emailSender:  emailSender,
+		events:       events,
+		config:       cfg,
+	}
+}
+
+func (s *DeviceServiceImpl) RecordLogin(ctx context.Context, user *models.User, method string) {
+	now := time.Now()
+	userAgent := contextutil.GetUserAgent(ctx)
+	deviceName := utils.DeviceName(userAgent)
+	fingerprint := deviceFingerprint(contextutil.GetDeviceID(ctx), deviceName)
+	country := contextutil.GetClientCountry(ctx)
+
+	devices, err := s.deviceRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		s.logError(ctx, user, "Failed to load known devices", err)
+		return
+	}
+
+	// The first sign-in only establishes what is familiar
+	newDevice, newCountry := len(devices) > 0, len(devices) > 0 && country != ""
+	for _, device := range devices {
+		if device.Fingerprint == fingerprint {
+			newDevice = false
+		}
+		if device.Country == country {
+			newCountry = false
+		}
+	}
+
+	err = s.deviceRepo.Upsert(ctx, &models.KnownDevice{
+		UserID:      user.ID,
+		Fingerprint: fingerprint,
+		Country:     country,
+		DeviceName:  deviceName,
+		IPAddress:   contextutil.GetClientIP(ctx),
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	})
+	if err != nil {
+		s.logError(ctx, user, "Failed to record known device", err)
+		return
+	}
+
+	if (!newDevice && !newCountry) || !s.config.LoginAlert.Enabled {
+		return
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		s.logError(ctx, user, "Failed to generate login alert token", err)
+		return
+	}
+
+	alert := &models.LoginAlert{
+		UserID:     user.ID,
+		TokenHash:  utils.HashToken(token),
+		DeviceName: deviceName,
+		IPAddress:  contextutil.GetClientIP(ctx),
+		Location:   truncate(contextutil.GetClientLocation(ctx), 100),
+		NewDevice:  newDevice,
+		NewCountry: newCountry,
+		ExpiresAt:  now.Add(s.config.LoginAlert.LinkTTL),
+		CreatedAt:  now,
+	}
+	if err := s.deviceRepo.CreateAlert(ctx, alert); err != nil {
+		s.logError(ctx, user, "Failed to create login alert", err)
+		return
+	}
+
+	logger.GetLogger().Info("Sign-in from unfamiliar device", map[string]interface{}{
+		"request_id":  contextutil.GetRequestID(ctx),
+		"action":      "login_new_device",
+		"user_id":     user.ID.String(),
+		"device":      deviceName,
+		"new_device":  newDevice,
+		"new_country": newCountry,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginNewDevice,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+		Metadata: map[string]interface{}{
+			"alert_id":    alert.ID.String(),
+			"device":      deviceName,
+			"country":     country,
+			"new_device":  newDevice,
+			"new_country": newCountry,
+			"method":      method,
+		},
+	})
+
+	s.notify(ctx, user, alert, token)
+	s.publishLoginAlert(ctx, user, alert, country, method)
+}
+
+func (s *DeviceServiceImpl) ConsumeAlert(ctx context.Context, token string) (*models.LoginAlert, error) {
+	now := time.Now()
+
+	alert, err := s.deviceRepo.GetAlertByTokenHash(ctx, utils.HashToken(token))
+	if err != nil || !alert.IsUsable(now) {
+		return nil, errors.New("link is invalid or has expired")
+	}
+
+	used, err := s.deviceRepo.MarkAlertUsed(ctx, alert.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, errors.New("link is invalid or has expired")
+	}
+
+	return alert, nil
+}
+
+// notify emails the account owner in the background so sign-in latency does not depend on SMTP
+func (s *DeviceServiceImpl) notify(ctx context.Context, user *models.User, alert *models.LoginAlert, token string) {
+	where := alert.IPAddress
+	if alert.Location != "" {
+		where = fmt.Sprintf("%s (%s)", alert.Location, alert.IPAddress)
+	}
+	notMeURL := fmt.Sprintf("%s/security/not-me?token=%s", s.config.App.FrontendURL, token)
+
+	message := &services.EmailMessage{
+		To:      user.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf(
+			"Your account was just signed in to from a device or location we have not seen before.\n\nDevice: %s\nLocation: %s\nTime: %s\n\nIf this was you, no action is needed.\n\nIf this wasn't you, sign out everywhere and reset your password: %s\n\nThis link expires in %s.",
+			alert.DeviceName, where, alert.CreatedAt.UTC().Format(time.RFC1123), notMeURL, s.config.LoginAlert.LinkTTL,
+		),
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.emailSender.Send(ctx, message); err != nil {
+			s.logError(ctx, user, "Failed to send new sign-in email", err)
+		}
+	}()
+}
+
+// publishLoginAlert publishes user.login.new_device through the EventEmitter
+func (s *DeviceServiceImpl) publishLoginAlert(ctx context.Context, user *models.User, alert *models.LoginAlert, country, method string) {
+	event, err := s.events.NewEvent(ctx, "user.login.new_device", user.ID.String(), &services.LoginAlertEventData{
+		UserID:     user.ID.String(),
+		DeviceName: alert.DeviceName,
+		IPAddress:  alert.IPAddress,
+		Country:    country,
+		Location:   alert.Location,
+		NewDevice:  alert.NewDevice,
+		NewCountry: alert.NewCountry,
+		Method:     method,
+	})
+	if err == nil {
+		event.Time = alert.CreatedAt.UTC()
+		err = s.events.PublishAsync(ctx, event)
+	}
+	if err != nil {
+		s.logError(ctx, user, "Failed to publish new sign-in event", err)
+	}
+}
+
+func (s *DeviceServiceImpl) logError(ctx context.Context, user *models.User, msg string, err error) {
+	logger.GetLogger().Error(msg, map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "login_new_device",
+		"user_id":    user.ID.String(),
+		"error":      err.Error(),
+	})
+}
+
+// deviceFingerprint combines the device cookie with the browser and OS family, so browser
+// updates keep the fingerprint while a copied cookie used elsewhere does not
+func deviceFingerprint(deviceID, deviceName string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(deviceID) + "\n" + deviceName))
+	return hex.EncodeToString(sum[:])
+}
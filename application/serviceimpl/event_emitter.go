@@ -0,0 +1,189 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/cloudevents"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+)
+
+// legacyServiceName is the service_name of legacy flat payloads
+const legacyServiceName = "gofiber-auth"
+
+// EventEmitter wraps events in CloudEvents envelopes and publishes them through the
+// EventPublisher: once per supported schema version on <subject>.v<N>.<topic>, and in the
+// legacy flat format on <subject>.<topic> while legacy payloads are enabled. Every event is
+// also queued for the webhook endpoints subscribed to its type.
+type EventEmitter struct {
+	publisher      services.EventPublisher // nil when NATS is unavailable
+	webhooks       services.WebhookService
+	source         string
+	schemaBaseURL  string
+	legacyPayloads bool
+}
+
+func NewEventEmitter(publisher services.EventPublisher, webhooks services.WebhookService, cfg *config.Config) *EventEmitter {
+	return &EventEmitter{
+		publisher:      publisher,
+		webhooks:       webhooks,
+		source:         cfg.Events.Source,
+		schemaBaseURL:  cfg.Events.SchemaBaseURL,
+		legacyPayloads: cfg.Events.LegacyPayloads,
+	}
+}
+
+// eventMessage is one NATS message of an event
+type eventMessage struct {
+	topic   string
+	msgID   string
+	payload interface{}
+}
+
+// Enabled reports whether events can be published to NATS
+func (e *EventEmitter) Enabled() bool {
+	return e.publisher != nil
+}
+
+// SchemaURL returns the dataschema URI of a type's data version
+func (e *EventEmitter) SchemaURL(eventType string, version int) string {
+	return services.EventSchemaURL(e.schemaBaseURL, eventType, version)
+}
+
+// NewEvent builds an event of a registered type with the current data version and a new ID.
+// subject is the ID of the entity the event is about.
+func (e *EventEmitter) NewEvent(ctx context.Context, eventType, subject string, data interface{}) (*cloudevents.Event, error) {
+	schema, ok := services.LookupEventSchema(eventType)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+
+	event, err := cloudevents.New(uuid.New().String(), e.source, eventType, subject, time.Now(), data)
+	if err != nil {
+		return nil, err
+	}
+	event.SchemaVersion = schema.Version
+	event.DataSchema = e.SchemaURL(eventType, schema.Version)
+	event.RequestID = contextutil.GetRequestID(ctx)
+	return event, nil
+}
+
+// Publish queues the event for webhooks, then sends every NATS message of the event and
+// returns the errors of those that failed. Queueing is idempotent per event ID, so a failed
+// event can be published again without duplicating webhook deliveries.
+func (e *EventEmitter) Publish(ctx context.Context, event *cloudevents.Event) error {
+	if err := e.EnqueueWebhooks(ctx, event); err != nil {
+		return err
+	}
+	if e.publisher == nil {
+		return errors.New("event publisher not available")
+	}
+
+	messages, err := e.messages(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, message := range messages {
+		if err := e.publisher.Publish(ctx, message.topic, message.msgID, message.payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", message.topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync queues the event for webhooks and sends it to NATS without waiting for
+// acknowledgements (fire-and-forget)
+func (e *EventEmitter) PublishAsync(ctx context.Context, event *cloudevents.Event) error {
+	if err := e.EnqueueWebhooks(ctx, event); err != nil {
+		return err
+	}
+	if e.publisher == nil {
+		return nil
+	}
+
+	messages, err := e.messages(event)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		e.publisher.PublishAsync(message.topic, message.msgID, message.payload)
+	}
+	return nil
+}
+
+// EnqueueWebhooks queues a delivery of the event to every active endpoint subscribed to its type.
+// Webhooks receive the current schema version only.
+func (e *EventEmitter) EnqueueWebhooks(ctx context.Context, event *cloudevents.Event) error {
+	if err := e.webhooks.Enqueue(ctx, event); err != nil {
+		return fmt.Errorf("failed to queue webhooks: %w", err)
+	}
+	return nil
+}
+
+// LegacyPayload returns the event in the flat pre-CloudEvents format: the data fields plus
+// event_id, request_id, timestamp and service_name
+func (e *EventEmitter) LegacyPayload(event *cloudevents.Event) (map[string]interface{}, error) {
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(event.Data, &payload); err != nil {
+		return nil, fmt.Errorf("invalid event data: %w", err)
+	}
+
+	payload["event_id"] = event.ID
+	payload["request_id"] = event.RequestID
+	payload["timestamp"] = event.Time.UTC().Format(time.RFC3339)
+	payload["service_name"] = legacyServiceName
+	return payload, nil
+}
+
+// messages expands the event (carrying current-version data) into one message per
+// supported version plus the legacy payload
+func (e *EventEmitter) messages(event *cloudevents.Event) ([]eventMessage, error) {
+	schema, ok := services.LookupEventSchema(event.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", event.Type)
+	}
+
+	topic := services.VersionedTopic(schema.Version, schema.Topic)
+	messages := []eventMessage{{topic: topic, msgID: messageID(event, topic), payload: event}}
+
+	if len(schema.Previous) > 0 {
+		current := reflect.New(reflect.TypeOf(schema.Data))
+		if err := json.Unmarshal(event.Data, current.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid event data: %w", err)
+		}
+
+		for _, previous := range schema.Previous {
+			downgraded, err := event.WithData(previous.Version, e.SchemaURL(event.Type, previous.Version), previous.Downgrade(current.Elem().Interface()))
+			if err != nil {
+				return nil, err
+			}
+			topic := services.VersionedTopic(previous.Version, schema.Topic)
+			messages = append(messages, eventMessage{topic: topic, msgID: messageID(event, topic), payload: downgraded})
+		}
+	}
+
+	if e.legacyPayloads {
+		legacy, err := e.LegacyPayload(event)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, eventMessage{topic: schema.Topic, msgID: messageID(event, schema.Topic), payload: legacy})
+	}
+
+	return messages, nil
+}
+
+// messageID returns the deduplication ID of the event's message on topic. Every message of an
+// event shares the event ID, so the topic tells them apart; retries reuse the same IDs.
+func messageID(event *cloudevents.Event, topic string) string {
+	return event.ID + ":" + topic
+}
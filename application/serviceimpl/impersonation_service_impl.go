@@ -0,0 +1,232 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// closeExpiredBatchSize bounds the work done by one scheduler run
+const closeExpiredBatchSize = 100
+
+type ImpersonationServiceImpl struct {
+	impersonationRepo repositories.ImpersonationRepository
+	userRepo          repositories.UserRepository
+	suspensionRepo    repositories.SuspensionRepository
+	rbacService       services.RBACService
+	tokenService      services.TokenService
+	auditService      services.AuditService
+	config            *config.Config
+}
+
+func NewImpersonationService(
+	impersonationRepo repositories.ImpersonationRepository,
+	userRepo repositories.UserRepository,
+	suspensionRepo repositories.SuspensionRepository,
+	rbacService services.RBACService,
+	tokenService services.TokenService,
+	auditService services.AuditService,
+	cfg *config.Config,
+) services.ImpersonationService {
+	return &ImpersonationServiceImpl{
+		impersonationRepo: impersonationRepo,
+		userRepo:          userRepo,
+		suspensionRepo:    suspensionRepo,
+		rbacService:       rbacService,
+		tokenService:      tokenService,
+		auditService:      auditService,
+		config:            cfg,
+	}
+}
+
+func (s *ImpersonationServiceImpl) Start(ctx context.Context, actorID, targetID uuid.UUID, req *dto.StartImpersonationRequest) (*dto.ImpersonationResponse, error) {
+	if actorID == targetID {
+		return nil, errors.New("cannot impersonate yourself")
+	}
+
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	target, err := s.userRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !target.IsActive {
+		return nil, errors.New("cannot impersonate a deactivated user")
+	}
+
+	now := time.Now()
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, targetID, now)
+	if err != nil {
+		return nil, err
+	}
+	if suspension != nil {
+		return nil, errors.New("cannot impersonate a suspended user")
+	}
+
+	// Impersonating another administrator would let staff borrow privileges they were not granted
+	roles, permissions, err := s.rbacService.ResolveAuthorization(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if containsString(roles, models.RoleAdmin) || containsString(permissions, models.PermUsersImpersonate) {
+		return nil, errors.New("administrators cannot be impersonated")
+	}
+
+	ttl := s.config.Admin.ImpersonationTTL
+	if req.DurationMinutes > 0 {
+		if requested := time.Duration(req.DurationMinutes) * time.Minute; requested < ttl {
+			ttl = requested
+		}
+	}
+
+	session := &models.ImpersonationSession{
+		ID:           uuid.New(),
+		ActorID:      actorID,
+		TargetUserID: targetID,
+		Reason:       strings.TrimSpace(req.Reason),
+		IPAddress:    contextutil.GetClientIP(ctx),
+		UserAgent:    truncate(contextutil.GetUserAgent(ctx), 500),
+		StartedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+
+	// The token is useless until its session row exists, so a failed insert leaves nothing usable behind
+	accessToken, err := s.tokenService.GenerateImpersonationToken(ctx, actor, target, session.ID, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.impersonationRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().Info("Impersonation started", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "impersonation_start",
+		"actor_id":   actorID.String(),
+		"user_id":    targetID.String(),
+		"session_id": session.ID.String(),
+		"reason":     session.Reason,
+		"ip_address": session.IPAddress,
+		"expires_at": session.ExpiresAt.UTC().Format(time.RFC3339),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditImpersonationStart,
+		ActorID:   &actorID,
+		SubjectID: &targetID,
+		Metadata: map[string]interface{}{
+			"session_id": session.ID.String(),
+			"reason":     session.Reason,
+			"expires_at": session.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return &dto.ImpersonationResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		Session:     *dto.ImpersonationSessionToResponse(session),
+	}, nil
+}
+
+func (s *ImpersonationServiceImpl) End(ctx context.Context, sessionID, endedBy uuid.UUID) error {
+	session, err := s.impersonationRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return errors.New("impersonation session not found")
+	}
+	if !session.IsActive(time.Now()) {
+		return errors.New("impersonation session is not active")
+	}
+
+	reason := models.ImpersonationEnded
+	if endedBy != session.ActorID {
+		reason = models.ImpersonationTerminated
+	}
+
+	return s.end(ctx, session, &endedBy, reason)
+}
+
+func (s *ImpersonationServiceImpl) ListForUser(ctx context.Context, targetID uuid.UUID) ([]*models.ImpersonationSession, error) {
+	return s.impersonationRepo.ListByTarget(ctx, targetID)
+}
+
+func (s *ImpersonationServiceImpl) CloseExpired(ctx context.Context) (int, error) {
+	expired, err := s.impersonationRepo.ListExpired(ctx, time.Now(), closeExpiredBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, session := range expired {
+		if err := s.end(ctx, session, nil, models.ImpersonationExpired); err != nil {
+			logger.GetLogger().Error("Failed to close expired impersonation session", map[string]interface{}{
+				"action":     "impersonation_end",
+				"session_id": session.ID.String(),
+				"error":      err.Error(),
+			})
+			continue
+		}
+		closed++
+	}
+
+	return closed, nil
+}
+
+// end closes a session; the token stops working because Authenticate checks the session row
+func (s *ImpersonationServiceImpl) end(ctx context.Context, session *models.ImpersonationSession, endedBy *uuid.UUID, reason string) error {
+	ok, err := s.impersonationRepo.End(ctx, session.ID, time.Now(), endedBy, reason)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Closed concurrently
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "impersonation_end",
+		"actor_id":   session.ActorID.String(),
+		"user_id":    session.TargetUserID.String(),
+		"session_id": session.ID.String(),
+		"end_reason": reason,
+	}
+	if endedBy != nil {
+		fields["ended_by"] = endedBy.String()
+	}
+	logger.GetLogger().Info("Impersonation ended", fields)
+
+	// Expired sessions are closed by the scheduler and have no actor
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditImpersonationEnd,
+		ActorID:   endedBy,
+		SubjectID: &session.TargetUserID,
+		Metadata: map[string]interface{}{
+			"session_id":      session.ID.String(),
+			"impersonated_by": session.ActorID.String(),
+			"end_reason":      reason,
+		},
+	})
+
+	return nil
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
@@ -0,0 +1,255 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LockoutServiceImpl struct {
+	attemptRepo  repositories.LoginAttemptRepository
+	userRepo     repositories.UserRepository
+	auditService services.AuditService
+	emailSender  services.EmailSender
+	config       *config.Config
+}
+
+func NewLockoutService(
+	attemptRepo repositories.LoginAttemptRepository,
+	userRepo repositories.UserRepository,
+	auditService services.AuditService,
+	emailSender services.EmailSender,
+	cfg *config.Config,
+) services.LockoutService {
+	return &LockoutServiceImpl{
+		attemptRepo:  attemptRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		emailSender:  emailSender,
+		config:       cfg,
+	}
+}
+
+func (s *LockoutServiceImpl) CheckLogin(ctx context.Context, email string) error {
+	now := time.Now()
+	policy := s.config.Lockout
+
+	account, err := s.attemptRepo.GetState(ctx, accountAttemptKey(email))
+	if err != nil {
+		// Fail open: an unavailable store must not prevent every sign-in
+		s.logStoreError(ctx, "login_lockout_check", err)
+		return nil
+	}
+	if account.LockedUntil != nil && account.LockedUntil.After(now) {
+		return &services.LoginThrottledError{RetryAfter: account.LockedUntil.Sub(now)}
+	}
+	if retryAt := s.backoffUntil(account); retryAt != nil && retryAt.After(now) {
+		return &services.LoginThrottledError{RetryAfter: retryAt.Sub(now)}
+	}
+
+	if clientIP := contextutil.GetClientIP(ctx); clientIP != "" && policy.MaxIPFailures > 0 {
+		ip, err := s.attemptRepo.GetState(ctx, ipAttemptKey(clientIP))
+		if err != nil {
+			s.logStoreError(ctx, "login_lockout_check", err)
+			return nil
+		}
+		if ip.LockedUntil != nil && ip.LockedUntil.After(now) {
+			return &services.LoginThrottledError{RetryAfter: ip.LockedUntil.Sub(now)}
+		}
+	}
+
+	return nil
+}
+
+func (s *LockoutServiceImpl) RecordFailure(ctx context.Context, email string, user *models.User) {
+	now := time.Now()
+	policy := s.config.Lockout
+	lockedUntil := now.Add(policy.Duration)
+
+	failures, err := s.attemptRepo.RecordFailure(ctx, accountAttemptKey(email), now, policy.Window)
+	if err != nil {
+		s.logStoreError(ctx, "login_lockout_record", err)
+		return
+	}
+	if policy.MaxAccountFailures > 0 && failures >= int64(policy.MaxAccountFailures) {
+		if err := s.attemptRepo.Lock(ctx, accountAttemptKey(email), lockedUntil); err != nil {
+			s.logStoreError(ctx, "login_lockout_record", err)
+		} else {
+			s.onLocked(ctx, "account", user, failures, lockedUntil)
+			if user != nil {
+				s.notifyLocked(ctx, user, lockedUntil)
+			}
+		}
+	}
+
+	clientIP := contextutil.GetClientIP(ctx)
+	if clientIP == "" || policy.MaxIPFailures <= 0 {
+		return
+	}
+	failures, err = s.attemptRepo.RecordFailure(ctx, ipAttemptKey(clientIP), now, policy.Window)
+	if err != nil {
+		s.logStoreError(ctx, "login_lockout_record", err)
+		return
+	}
+	if failures >= int64(policy.MaxIPFailures) {
+		if err := s.attemptRepo.Lock(ctx, ipAttemptKey(clientIP), lockedUntil); err != nil {
+			s.logStoreError(ctx, "login_lockout_record", err)
+		} else {
+			s.onLocked(ctx, "ip", nil, failures, lockedUntil)
+		}
+	}
+}
+
+func (s *LockoutServiceImpl) RecordSuccess(ctx context.Context, email string) {
+	// Only the account is cleared; an IP that guessed many accounts stays suspicious
+	if err := s.attemptRepo.Clear(ctx, accountAttemptKey(email)); err != nil {
+		s.logStoreError(ctx, "login_lockout_clear", err)
+	}
+}
+
+func (s *LockoutServiceImpl) GetStatus(ctx context.Context, userID uuid.UUID) (*dto.LockoutStatusResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	state, err := s.attemptRepo.GetState(ctx, accountAttemptKey(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.LockoutStatusResponse{
+		FailedAttempts: state.Failures,
+		LastFailureAt:  state.LastFailureAt,
+	}
+	if state.LockedUntil != nil && state.LockedUntil.After(time.Now()) {
+		status.Locked = true
+		status.LockedUntil = state.LockedUntil
+	}
+	return status, nil
+}
+
+func (s *LockoutServiceImpl) Unlock(ctx context.Context, actorID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	if err := s.attemptRepo.Clear(ctx, accountAttemptKey(user.Email)); err != nil {
+		return err
+	}
+
+	logger.GetLogger().Info("User account unlocked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "user_unlock",
+		"actor_id":   actorID.String(),
+		"user_id":    userID.String(),
+	})
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditUserUnlocked,
+		ActorID:   &actorID,
+		SubjectID: &userID,
+	})
+
+	return nil
+}
+
+// backoffUntil returns when the next attempt is allowed, doubling the delay with every failure
+// past the threshold. nil means no delay applies.
+func (s *LockoutServiceImpl) backoffUntil(state *repositories.LoginAttemptState) *time.Time {
+	policy := s.config.Lockout
+	if policy.BackoffAfter <= 0 || state.LastFailureAt == nil || state.Failures < int64(policy.BackoffAfter) {
+		return nil
+	}
+
+	delay := policy.BackoffBase
+	for i := int64(policy.BackoffAfter); i < state.Failures && delay < policy.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > policy.BackoffMax {
+		delay = policy.BackoffMax
+	}
+
+	retryAt := state.LastFailureAt.Add(delay)
+	return &retryAt
+}
+
+func (s *LockoutServiceImpl) onLocked(ctx context.Context, scope string, user *models.User, failures int64, lockedUntil time.Time) {
+	logger.GetLogger().Warn("Sign-in locked after repeated failures", map[string]interface{}{
+		"request_id":   contextutil.GetRequestID(ctx),
+		"action":       "login_lockout",
+		"scope":        scope,
+		"failures":     failures,
+		"locked_until": lockedUntil.UTC().Format(time.RFC3339),
+	})
+
+	event := &models.AuditEvent{
+		EventType: models.AuditAccountLocked,
+		Outcome:   models.AuditOutcomeFailure,
+		Metadata: map[string]interface{}{
+			"scope":       scope,
+			"failures":    failures,
+			"lockedUntil": lockedUntil.UTC().Format(time.RFC3339),
+		},
+	}
+	if user != nil {
+		event.SubjectID = &user.ID
+	}
+	s.auditService.Record(ctx, event)
+}
+
+// notifyLocked emails the account owner in the background so the response time does not
+// reveal whether the account exists
+func (s *LockoutServiceImpl) notifyLocked(ctx context.Context, user *models.User, lockedUntil time.Time) {
+	message := &services.EmailMessage{
+		To:      user.Email,
+		Subject: "Your account has been temporarily locked",
+		Body: fmt.Sprintf(
+			"We blocked sign-ins to your account until %s after several failed attempts.\n\nIf this was not you, reset your password: %s/forgot-password",
+			lockedUntil.UTC().Format(time.RFC1123), s.config.App.FrontendURL,
+		),
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.emailSender.Send(ctx, message); err != nil {
+			logger.GetLogger().Error("Failed to send lockout email", map[string]interface{}{
+				"request_id": contextutil.GetRequestID(ctx),
+				"action":     "login_lockout",
+				"user_id":    user.ID.String(),
+				"error":      err.Error(),
+			})
+		}
+	}()
+}
+
+func (s *LockoutServiceImpl) logStoreError(ctx context.Context, action string, err error) {
+	logger.GetLogger().Error("Login attempt store unavailable", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     action,
+		"error":      err.Error(),
+	})
+}
+
+// accountAttemptKey identifies an email address without storing it in clear
+func accountAttemptKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return "account:" + hex.EncodeToString(sum[:16])
+}
+
+func ipAttemptKey(clientIP string) string {
+	return "ip:" + clientIP
+}
@@ -3,6 +3,7 @@ package serviceimpl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -23,20 +24,30 @@ import (
 	"gorm.io/datatypes"
 )
 
+var errProviderNotLinked = errors.New("provider is not linked to this account")
+
 type oauthService struct {
-	userRepo        repositories.UserRepository
-	oauthRepo       repositories.OAuthRepository
-	userService     services.UserService
-	syncService     *SyncService
-	googleConfig    *oauth2.Config
-	facebookConfig  *oauth2.Config
-	lineConfig      *oauth2.Config
+	userRepo          repositories.UserRepository
+	oauthRepo         repositories.OAuthRepository
+	userService       services.UserService
+	suspensionService services.SuspensionService
+	deviceService     services.DeviceService
+	riskService       services.RiskService
+	auditService      services.AuditService
+	syncService       *SyncService
+	googleConfig      *oauth2.Config
+	facebookConfig    *oauth2.Config
+	lineConfig        *oauth2.Config
 }
 
 func NewOAuthService(
 	userRepo repositories.UserRepository,
 	oauthRepo repositories.OAuthRepository,
 	userService services.UserService,
+	suspensionService services.SuspensionService,
+	deviceService services.DeviceService,
+	riskService services.RiskService,
+	auditService services.AuditService,
 	syncService *SyncService,
 	cfg *config.Config,
 ) services.OAuthService {
@@ -74,13 +85,17 @@ func NewOAuthService(
 	}
 
 	return &oauthService{
-		userRepo:       userRepo,
-		oauthRepo:      oauthRepo,
-		userService:    userService,
-		syncService:    syncService,
-		googleConfig:   googleConfig,
-		facebookConfig: facebookConfig,
-		lineConfig:     lineConfig,
+		userRepo:          userRepo,
+		oauthRepo:         oauthRepo,
+		userService:       userService,
+		suspensionService: suspensionService,
+		deviceService:     deviceService,
+		riskService:       riskService,
+		auditService:      auditService,
+		syncService:       syncService,
+		googleConfig:      googleConfig,
+		facebookConfig:    facebookConfig,
+		lineConfig:        lineConfig,
 	}
 }
 
@@ -163,8 +178,13 @@ func (s *oauthService) HandleGoogleCallback(ctx context.Context, code string) (*
 		return nil, "", false, err
 	}
 
+	// Disabled and suspended accounts cannot sign in through OAuth either
+	if err := s.checkSignIn(ctx, user, "google"); err != nil {
+		return nil, "", false, err
+	}
+
 	// Generate JWT
-	jwtToken, err := s.userService.GenerateJWT(user)
+	jwtToken, err := s.userService.GenerateJWT(ctx, user)
 	if err != nil {
 		log.Error("JWT generation failed", map[string]interface{}{
 			"request_id": requestID,
@@ -184,6 +204,8 @@ func (s *oauthService) HandleGoogleCallback(ctx context.Context, code string) (*
 		"duration_ms": duration,
 	})
 
+	s.recordSignIn(ctx, user, "google", isNewUser)
+
 	return user, jwtToken, isNewUser, nil
 }
 
@@ -219,12 +241,19 @@ func (s *oauthService) HandleFacebookCallback(ctx context.Context, code string)
 		return nil, "", false, err
 	}
 
+	// Disabled and suspended accounts cannot sign in through OAuth either
+	if err := s.checkSignIn(ctx, user, "facebook"); err != nil {
+		return nil, "", false, err
+	}
+
 	// Generate JWT
-	jwtToken, err := s.userService.GenerateJWT(user)
+	jwtToken, err := s.userService.GenerateJWT(ctx, user)
 	if err != nil {
 		return nil, "", false, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
+	s.recordSignIn(ctx, user, "facebook", isNewUser)
+
 	return user, jwtToken, isNewUser, nil
 }
 
@@ -274,17 +303,62 @@ func (s *oauthService) HandleLINECallback(ctx context.Context, code string) (*mo
 		return nil, "", false, err
 	}
 
+	// Disabled and suspended accounts cannot sign in through OAuth either
+	if err := s.checkSignIn(ctx, user, "line"); err != nil {
+		return nil, "", false, err
+	}
+
 	// Generate JWT
-	jwtToken, err := s.userService.GenerateJWT(user)
+	jwtToken, err := s.userService.GenerateJWT(ctx, user)
 	if err != nil {
 		return nil, "", false, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
+	s.recordSignIn(ctx, user, "line", isNewUser)
+
 	return user, jwtToken, isNewUser, nil
 }
 
 // ==================== Helper Methods ====================
 
+func (s *oauthService) checkSignIn(ctx context.Context, user *models.User, provider string) error {
+	if !user.IsActive {
+		s.recordSignInFailure(ctx, user, provider, "account_disabled")
+		return fmt.Errorf("account is disabled")
+	}
+	if err := s.suspensionService.CheckSignIn(ctx, user.ID); err != nil {
+		s.recordSignInFailure(ctx, user, provider, "account_suspended")
+		return err
+	}
+	if err := s.riskService.CheckLogin(ctx, user, "oauth"); err != nil {
+		if errors.Is(err, services.ErrLoginDenied) {
+			s.recordSignInFailure(ctx, user, provider, "risk_denied")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *oauthService) recordSignInFailure(ctx context.Context, user *models.User, provider, reason string) {
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginFailed,
+		Outcome:   models.AuditOutcomeFailure,
+		SubjectID: &user.ID,
+		Metadata:  map[string]interface{}{"method": "oauth", "provider": provider, "reason": reason},
+	})
+}
+
+func (s *oauthService) recordSignIn(ctx context.Context, user *models.User, provider string, isNewUser bool) {
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginSucceeded,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+		Metadata:  map[string]interface{}{"method": "oauth", "provider": provider, "new_user": isNewUser},
+	})
+	s.deviceService.RecordLogin(ctx, user, "oauth")
+	s.syncService.SyncUserEventAsync(ctx, user, services.UserEventData{Action: "logged_in", Method: "oauth", Provider: provider})
+}
+
 func (s *oauthService) findOrCreateOAuthUser(
 	ctx context.Context,
 	provider string,
@@ -356,6 +430,7 @@ func (s *oauthService) findOrCreateOAuthUser(
 	} else {
 		// Create new user
 		user = &models.User{
+			ID:            uuid.New(), // Needed by the created event before the insert
 			Email:         email,
 			Username:      username,
 			Password:      nil, // OAuth users don't have password
@@ -369,13 +444,10 @@ func (s *oauthService) findOrCreateOAuthUser(
 			IsActive:      true,
 		}
 
-		if err := s.userRepo.Create(ctx, user); err != nil {
+		if err := s.userRepo.Create(ctx, user, s.syncService.UserEvent(ctx, user, "created")); err != nil {
 			return nil, false, fmt.Errorf("failed to create user: %w", err)
 		}
 		isNewUser = true
-
-		// Sync new OAuth user to backend (async with context)
-		go s.syncService.SyncUserWithRetry(ctx, user, "created")
 	}
 
 	// Create OAuth provider record
@@ -397,16 +469,93 @@ func (s *oauthService) findOrCreateOAuthUser(
 		return nil, false, fmt.Errorf("failed to create oauth provider: %w", err)
 	}
 
-	// Update last login
+	if !isNewUser {
+		s.auditService.Record(ctx, &models.AuditEvent{
+			EventType: models.AuditProviderLinked,
+			ActorID:   &user.ID,
+			SubjectID: &user.ID,
+			Metadata:  map[string]interface{}{"provider": provider},
+		})
+	}
+
+	// Update last login; a provider added to an existing account is published with it
+	var events []*models.OutboxEvent
+	if !isNewUser {
+		events = append(events, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+			Action:   "provider_linked",
+			Provider: provider,
+		}))
+	}
 	now := time.Now()
 	user.LastLoginAt = &now
-	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+	if err := s.userRepo.Update(ctx, user.ID, user, events...); err != nil {
 		return nil, false, fmt.Errorf("failed to update user: %w", err)
 	}
 
 	return user, isNewUser, nil
 }
 
+// UnlinkProvider removes a sign-in provider from the user's account. The last way to sign in
+// (a password or another provider) cannot be removed.
+func (s *oauthService) UnlinkProvider(ctx context.Context, userID uuid.UUID, provider string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	providers, err := s.oauthRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var linked *models.OAuthProvider
+	for _, p := range providers {
+		if p.Provider == provider {
+			linked = p
+			break
+		}
+	}
+	if linked == nil {
+		return errProviderNotLinked
+	}
+	if user.Password == nil && len(providers) == 1 {
+		return errors.New("cannot unlink the only sign-in method, set a password first")
+	}
+
+	if err := s.oauthRepo.Delete(ctx, linked.ID); err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{"updated_at": time.Now()}
+	if user.OAuthProvider == provider {
+		// The account was created through this provider
+		fields["oauth_provider"] = ""
+		fields["oauth_id"] = ""
+	}
+	if err := s.userRepo.UpdateFields(ctx, userID, fields, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action:   "provider_unlinked",
+		Provider: provider,
+	})); err != nil {
+		return err
+	}
+
+	logger.GetLogger().Info("OAuth provider unlinked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "provider_unlink",
+		"user_id":    userID.String(),
+		"provider":   provider,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditProviderUnlinked,
+		ActorID:   &userID,
+		SubjectID: &userID,
+		Metadata:  map[string]interface{}{"provider": provider},
+	})
+
+	return nil
+}
+
 func (s *oauthService) generateUsername(email, displayName string) string {
 	// Try email username first
 	if email != "" && !strings.HasPrefix(email, "line_") {
@@ -0,0 +1,496 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errNotOrgMember      = errors.New("organization not found")
+	errInsufficientRole  = errors.New("insufficient organization role")
+	errLastOwner         = errors.New("an organization must keep at least one owner")
+	errInvitationInvalid = errors.New("invitation is invalid or has expired")
+)
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+type OrganizationServiceImpl struct {
+	orgRepo        repositories.OrganizationRepository
+	userRepo       repositories.UserRepository
+	revocationRepo repositories.TokenRevocationRepository
+	tokenService   services.TokenService
+	emailSender    services.EmailSender
+	syncService    *SyncService
+	config         *config.Config
+}
+
+func NewOrganizationService(
+	orgRepo repositories.OrganizationRepository,
+	userRepo repositories.UserRepository,
+	revocationRepo repositories.TokenRevocationRepository,
+	tokenService services.TokenService,
+	emailSender services.EmailSender,
+	syncService *SyncService,
+	cfg *config.Config,
+) services.OrganizationService {
+	return &OrganizationServiceImpl{
+		orgRepo:        orgRepo,
+		userRepo:       userRepo,
+		revocationRepo: revocationRepo,
+		tokenService:   tokenService,
+		emailSender:    emailSender,
+		syncService:    syncService,
+		config:         cfg,
+	}
+}
+
+// ==================== Organizations ====================
+
+func (s *OrganizationServiceImpl) CreateOrganization(ctx context.Context, actorID uuid.UUID, req *dto.CreateOrganizationRequest) (*models.Organization, error) {
+	slug := slugify(req.Slug)
+	if slug == "" {
+		slug = slugify(req.Name)
+	}
+	if slug == "" {
+		return nil, errors.New("organization slug is invalid")
+	}
+
+	if existing, _ := s.orgRepo.GetBySlug(ctx, slug); existing != nil {
+		if req.Slug != "" {
+			return nil, errors.New("organization slug already exists")
+		}
+		// Derived slugs get a random suffix instead of failing
+		slug = slug + "-" + uuid.New().String()[:6]
+	}
+
+	org := &models.Organization{
+		ID:        uuid.New(), // Known up front for the membership event
+		Name:      req.Name,
+		Slug:      slug,
+		CreatedBy: actorID,
+	}
+	owner := &models.OrganizationMember{
+		UserID: actorID,
+		Role:   models.OrgRoleOwner,
+	}
+
+	event := s.membershipEvent(ctx, "added", org.ID, actorID, models.OrgRoleOwner, "", actorID)
+	if err := s.orgRepo.Create(ctx, org, owner, event); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().Info("Organization created", map[string]interface{}{
+		"request_id":      contextutil.GetRequestID(ctx),
+		"action":          "org_create",
+		"user_id":         actorID.String(),
+		"organization_id": org.ID.String(),
+		"slug":            org.Slug,
+	})
+
+	return org, nil
+}
+
+func (s *OrganizationServiceImpl) ListMyOrganizations(ctx context.Context, actorID uuid.UUID) ([]*models.OrganizationMember, error) {
+	return s.orgRepo.ListMembershipsByUser(ctx, actorID)
+}
+
+func (s *OrganizationServiceImpl) GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*models.OrganizationMember, error) {
+	return s.requireRole(ctx, orgID, actorID, models.OrgRoleMember)
+}
+
+func (s *OrganizationServiceImpl) UpdateOrganization(ctx context.Context, actorID, orgID uuid.UUID, req *dto.UpdateOrganizationRequest) (*models.Organization, error) {
+	membership, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	org := membership.Organization
+	org.Name = req.Name
+	org.UpdatedAt = time.Now()
+	if err := s.orgRepo.Update(ctx, &org); err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+func (s *OrganizationServiceImpl) DeleteOrganization(ctx context.Context, actorID, orgID uuid.UUID) error {
+	if _, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	members, err := s.orgRepo.ListMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	events := make([]*models.OutboxEvent, len(members))
+	for i, member := range members {
+		events[i] = s.membershipEvent(ctx, "removed", orgID, member.UserID, "", member.Role, actorID)
+	}
+	if err := s.orgRepo.Delete(ctx, orgID, events...); err != nil {
+		return err
+	}
+	for _, member := range members {
+		s.revokeMemberTokens(ctx, orgID, member.UserID)
+	}
+
+	logger.GetLogger().Info("Organization deleted", map[string]interface{}{
+		"request_id":      contextutil.GetRequestID(ctx),
+		"action":          "org_delete",
+		"user_id":         actorID.String(),
+		"organization_id": orgID.String(),
+		"members":         len(members),
+	})
+
+	return nil
+}
+
+// ==================== Members ====================
+
+func (s *OrganizationServiceImpl) ListMembers(ctx context.Context, actorID, orgID uuid.UUID) ([]*models.OrganizationMember, error) {
+	if _, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+func (s *OrganizationServiceImpl) UpdateMemberRole(ctx context.Context, actorID, orgID, userID uuid.UUID, role string) error {
+	actor, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return errors.New("member not found")
+	}
+
+	// Admins cannot act on or grant roles above their own
+	if models.OrgRoleRank[target.Role] > models.OrgRoleRank[actor.Role] ||
+		models.OrgRoleRank[role] > models.OrgRoleRank[actor.Role] {
+		return errInsufficientRole
+	}
+
+	if target.Role == role {
+		return nil
+	}
+
+	if target.Role == models.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	event := s.membershipEvent(ctx, "role_changed", orgID, userID, role, target.Role, actorID)
+	if err := s.orgRepo.UpdateMemberRole(ctx, orgID, userID, role, event); err != nil {
+		return err
+	}
+
+	// The org_role claim in tokens scoped to this organization is now stale
+	s.revokeMemberTokens(ctx, orgID, userID)
+	return nil
+}
+
+func (s *OrganizationServiceImpl) RemoveMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error {
+	target, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Members may always leave; removing others requires admin and a role at least as high
+	if actorID != userID {
+		actor, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin)
+		if err != nil {
+			return err
+		}
+		if target != nil && models.OrgRoleRank[target.Role] > models.OrgRoleRank[actor.Role] {
+			return errInsufficientRole
+		}
+	}
+
+	if target == nil {
+		return errors.New("member not found")
+	}
+
+	if target.Role == models.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	event := s.membershipEvent(ctx, "removed", orgID, userID, "", target.Role, actorID)
+	if err := s.orgRepo.RemoveMember(ctx, orgID, userID, event); err != nil {
+		return err
+	}
+
+	// Tokens may still carry the removed organization as the active org
+	s.revokeMemberTokens(ctx, orgID, userID)
+	return nil
+}
+
+// ==================== Invitations ====================
+
+func (s *OrganizationServiceImpl) CreateInvitation(ctx context.Context, actorID, orgID uuid.UUID, req *dto.CreateInvitationRequest) (*models.OrganizationInvitation, error) {
+	actor, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if models.OrgRoleRank[req.Role] > models.OrgRoleRank[actor.Role] {
+		return nil, errInsufficientRole
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if invitee, _ := s.userRepo.GetByEmail(ctx, email); invitee != nil {
+		if member, _ := s.orgRepo.GetMember(ctx, orgID, invitee.ID); member != nil {
+			return nil, errors.New("user is already a member of this organization")
+		}
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new invitation supersedes any pending one for the same email
+	pending, err := s.orgRepo.ListPendingInvitations(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, existing := range pending {
+		if existing.Email == email {
+			existing.RevokedAt = &now
+			if err := s.orgRepo.UpdateInvitation(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	invitation := &models.OrganizationInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           req.Role,
+		TokenHash:      utils.HashToken(token),
+		InvitedBy:      actorID,
+		ExpiresAt:      now.Add(s.config.Org.InvitationTTL),
+		CreatedAt:      now,
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	acceptURL := fmt.Sprintf("%s/invitations/accept?token=%s", s.config.App.FrontendURL, token)
+	message := &services.EmailMessage{
+		To:      email,
+		Subject: fmt.Sprintf("You have been invited to join %s", actor.Organization.Name),
+		Body: fmt.Sprintf(
+			"You have been invited to join %s as %s.\n\nAccept the invitation: %s\n\nThis invitation expires on %s.",
+			actor.Organization.Name, req.Role, acceptURL, invitation.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+	if err := s.emailSender.Send(ctx, message); err != nil {
+		logger.GetLogger().Warn("Failed to send invitation email", map[string]interface{}{
+			"request_id":      contextutil.GetRequestID(ctx),
+			"action":          "org_invite",
+			"organization_id": orgID.String(),
+			"invitation_id":   invitation.ID.String(),
+			"error":           err.Error(),
+		})
+	}
+
+	logger.GetLogger().Info("Organization invitation created", map[string]interface{}{
+		"request_id":      contextutil.GetRequestID(ctx),
+		"action":          "org_invite",
+		"user_id":         actorID.String(),
+		"organization_id": orgID.String(),
+		"invitation_id":   invitation.ID.String(),
+		"role":            req.Role,
+	})
+
+	return invitation, nil
+}
+
+func (s *OrganizationServiceImpl) ListInvitations(ctx context.Context, actorID, orgID uuid.UUID) ([]*models.OrganizationInvitation, error) {
+	if _, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListPendingInvitations(ctx, orgID)
+}
+
+func (s *OrganizationServiceImpl) RevokeInvitation(ctx context.Context, actorID, orgID, invitationID uuid.UUID) error {
+	if _, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	invitation, err := s.orgRepo.GetInvitationByID(ctx, invitationID)
+	if err != nil || invitation.OrganizationID != orgID {
+		return errors.New("invitation not found")
+	}
+
+	if !invitation.IsPending(time.Now()) {
+		return errInvitationInvalid
+	}
+
+	now := time.Now()
+	invitation.RevokedAt = &now
+	return s.orgRepo.UpdateInvitation(ctx, invitation)
+}
+
+func (s *OrganizationServiceImpl) AcceptInvitation(ctx context.Context, actorID uuid.UUID, token string) (*models.OrganizationMember, error) {
+	invitation, err := s.orgRepo.GetInvitationByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, errInvitationInvalid
+	}
+
+	now := time.Now()
+	if !invitation.IsPending(now) {
+		return nil, errInvitationInvalid
+	}
+
+	user, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	// Invitations are bound to the invited address
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errors.New("invitation was sent to a different email address")
+	}
+
+	if existing, _ := s.orgRepo.GetMember(ctx, invitation.OrganizationID, actorID); existing != nil {
+		return nil, errors.New("user is already a member of this organization")
+	}
+
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = &actorID
+	member := &models.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         actorID,
+		Role:           invitation.Role,
+	}
+	event := s.membershipEvent(ctx, "added", invitation.OrganizationID, actorID, invitation.Role, "", invitation.InvitedBy)
+	if err := s.orgRepo.AcceptInvitation(ctx, invitation, member, event); err != nil {
+		return nil, err
+	}
+	member.Organization = invitation.Organization
+
+	logger.GetLogger().Info("Organization invitation accepted", map[string]interface{}{
+		"request_id":      contextutil.GetRequestID(ctx),
+		"action":          "org_invite_accept",
+		"user_id":         actorID.String(),
+		"organization_id": invitation.OrganizationID.String(),
+		"invitation_id":   invitation.ID.String(),
+	})
+
+	return member, nil
+}
+
+// ==================== Active Organization ====================
+
+func (s *OrganizationServiceImpl) SwitchOrganization(ctx context.Context, actorID, orgID uuid.UUID) (string, *models.OrganizationMember, error) {
+	if contextutil.GetImpersonatorID(ctx) != uuid.Nil {
+		return "", nil, errImpersonatedSwitch
+	}
+
+	membership, err := s.requireRole(ctx, orgID, actorID, models.OrgRoleMember)
+	if err != nil {
+		return "", nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return "", nil, errors.New("user not found")
+	}
+
+	// Remember the choice so future logins start in this organization
+	if err := s.orgRepo.TouchMember(ctx, orgID, actorID, time.Now()); err != nil {
+		return "", nil, err
+	}
+
+	token, err := s.tokenService.GenerateAccessTokenForMembership(ctx, user, membership)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, membership, nil
+}
+
+// ==================== Helper Methods ====================
+
+// requireRole returns the actor's membership if it has at least minRole.
+// Non-members get a not-found error so organization IDs are not disclosed.
+func (s *OrganizationServiceImpl) requireRole(ctx context.Context, orgID, userID uuid.UUID, minRole string) (*models.OrganizationMember, error) {
+	membership, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, errNotOrgMember
+	}
+	if models.OrgRoleRank[membership.Role] < models.OrgRoleRank[minRole] {
+		return nil, errInsufficientRole
+	}
+	return membership, nil
+}
+
+func (s *OrganizationServiceImpl) ensureAnotherOwner(ctx context.Context, orgID uuid.UUID) error {
+	owners, err := s.orgRepo.CountMembersWithRole(ctx, orgID, models.OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errLastOwner
+	}
+	return nil
+}
+
+func (s *OrganizationServiceImpl) revokeMemberTokens(ctx context.Context, orgID, userID uuid.UUID) {
+	if err := s.revocationRepo.RevokeMembershipTokens(ctx, userID, orgID, time.Now()); err != nil {
+		logger.GetLogger().Warn("Failed to revoke tokens after membership change", map[string]interface{}{
+			"request_id":      contextutil.GetRequestID(ctx),
+			"user_id":         userID.String(),
+			"organization_id": orgID.String(),
+			"error":           err.Error(),
+		})
+	}
+}
+
+// membershipEvent builds the outbox event for membership.{action}
+func (s *OrganizationServiceImpl) membershipEvent(ctx context.Context, action string, orgID, userID uuid.UUID, role, previousRole string, actorID uuid.UUID) *models.OutboxEvent {
+	return s.syncService.MembershipEvent(ctx, userID, services.MembershipEventData{
+		OrganizationID: orgID.String(),
+		Role:           role,
+		PreviousRole:   previousRole,
+		Action:         action,
+		ActorID:        actorID.String(),
+	})
+}
+
+// slugify converts a name into a URL-safe organization slug
+func slugify(value string) string {
+	slug := slugInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(value)), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > 90 {
+		slug = strings.Trim(slug[:90], "-")
+	}
+	return slug
+}
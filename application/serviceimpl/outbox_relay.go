@@ -0,0 +1,192 @@
+package serviceimpl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/metrics"
+)
+
+// outboxLease hides claimed events from other relays while they are being published.
+// A relay that dies mid-batch leaves its events to be picked up again after the lease.
+const outboxLease = 5 * time.Minute
+
+// OutboxRelay publishes events stored in the outbox table by the SyncService.
+// Delivery is at-least-once: an event is marked published only after the publisher
+// acknowledged it, and failed events are retried with exponential backoff until they
+// are moved to the dead-letter queue.
+type OutboxRelay struct {
+	outboxRepo  repositories.OutboxRepository
+	syncService *SyncService
+	deadLetters services.DeadLetterService
+	cfg         config.OutboxConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewOutboxRelay(
+	outboxRepo repositories.OutboxRepository,
+	syncService *SyncService,
+	deadLetters services.DeadLetterService,
+	cfg *config.Config,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo:  outboxRepo,
+		syncService: syncService,
+		deadLetters: deadLetters,
+		cfg:         cfg.Outbox,
+	}
+}
+
+// Start runs the relay loop in the background until Stop is called
+func (r *OutboxRelay) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.run(ctx)
+}
+
+// Stop ends the relay loop and waits for the batch in progress to finish
+func (r *OutboxRelay) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel == nil {
+		return
+	}
+
+	r.cancel()
+	<-r.done
+	r.cancel = nil
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	defer close(r.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Keep draining while there is work; otherwise wait for the next poll
+		published, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.GetLogger().Error("Outbox relay failed", map[string]interface{}{
+				"action": "outbox_relay",
+				"error":  err.Error(),
+			})
+		}
+
+		if published > 0 {
+			timer.Reset(0)
+		} else {
+			timer.Reset(r.cfg.PollInterval)
+		}
+	}
+}
+
+// RelayPending publishes one batch of due events and returns how many were published
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	events, err := r.outboxRepo.ClaimPending(ctx, now, now.Add(outboxLease), r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Record outcomes even when shutdown cancels ctx mid-batch
+	markCtx := context.WithoutCancel(ctx)
+
+	log := logger.GetLogger()
+	published := 0
+	for _, event := range events {
+		if err := r.syncService.PublishOutboxEvent(ctx, event); err != nil {
+			metrics.OutboxEventsRelayedTotal.WithLabelValues(event.Topic, "failure").Inc()
+
+			if r.cfg.MaxAttempts > 0 && event.Attempts+1 >= r.cfg.MaxAttempts {
+				// Later events of the same user are unblocked once this one leaves the outbox
+				if err := r.deadLetter(markCtx, event, err); err != nil {
+					return published, err
+				}
+				continue
+			}
+
+			delay := r.retryDelay(event.Attempts)
+			log.Warn("Outbox event publish failed", map[string]interface{}{
+				"action":   "outbox_relay",
+				"event_id": event.ID.String(),
+				"user_id":  event.AggregateID.String(),
+				"topic":    event.Topic,
+				"attempt":  event.Attempts + 1,
+				"delay_ms": delay.Milliseconds(),
+				"error":    err.Error(),
+			})
+			if err := r.outboxRepo.MarkFailed(markCtx, event.ID, err.Error(), time.Now().Add(delay)); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		metrics.OutboxEventsRelayedTotal.WithLabelValues(event.Topic, "success").Inc()
+		if err := r.outboxRepo.MarkPublished(markCtx, event.ID, time.Now()); err != nil {
+			// The event stays leased and is published again after the lease; consumers deduplicate
+			return published, err
+		}
+		published++
+	}
+
+	if pending, err := r.outboxRepo.CountPending(ctx); err == nil {
+		metrics.OutboxPendingEvents.Set(float64(pending))
+	}
+
+	return published, nil
+}
+
+func (r *OutboxRelay) deadLetter(ctx context.Context, event *models.OutboxEvent, cause error) error {
+	now := time.Now()
+	return r.deadLetters.Add(ctx, &models.DeadLetterEvent{
+		ID:            event.ID,
+		AggregateID:   event.AggregateID,
+		Topic:         event.Topic,
+		Payload:       event.Payload,
+		Source:        models.DeadLetterSourceOutbox,
+		Attempts:      event.Attempts + 1,
+		LastError:     cause.Error(),
+		CreatedAt:     now,
+		LastAttemptAt: now,
+	})
+}
+
+// DeletePublished removes published events older than the retention period
+func (r *OutboxRelay) DeletePublished(ctx context.Context) (int64, error) {
+	return r.outboxRepo.DeletePublishedBefore(ctx, time.Now().Add(-r.cfg.Retention))
+}
+
+// retryDelay returns the backoff after the given number of earlier failed attempts
+func (r *OutboxRelay) retryDelay(attempts int) time.Duration {
+	delay := r.cfg.RetryBase
+	for i := 0; i < attempts && delay < r.cfg.RetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, r.cfg.RetryMax)
+}
@@ -0,0 +1,272 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPersonalTokenTTL  = 90 * 24 * time.Hour
+	maxActivePersonalTokens  = 50
+	personalTokenDisplayLen  = 12          // "pat_" plus the first 8 characters
+	personalTokenTouchPeriod = time.Minute // Throttles last_used_at writes
+)
+
+type PersonalAccessTokenServiceImpl struct {
+	patRepo        repositories.PersonalAccessTokenRepository
+	userRepo       repositories.UserRepository
+	suspensionRepo repositories.SuspensionRepository
+	rbacService    services.RBACService
+	auditService   services.AuditService
+}
+
+func NewPersonalAccessTokenService(
+	patRepo repositories.PersonalAccessTokenRepository,
+	userRepo repositories.UserRepository,
+	suspensionRepo repositories.SuspensionRepository,
+	rbacService services.RBACService,
+	auditService services.AuditService,
+) services.PersonalAccessTokenService {
+	return &PersonalAccessTokenServiceImpl{
+		patRepo:        patRepo,
+		userRepo:       userRepo,
+		suspensionRepo: suspensionRepo,
+		rbacService:    rbacService,
+		auditService:   auditService,
+	}
+}
+
+func (s *PersonalAccessTokenServiceImpl) CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreatePersonalAccessTokenRequest) (*models.PersonalAccessToken, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", errors.New("user not found")
+	}
+
+	_, permissions, err := s.rbacService.ResolveAuthorization(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Tokens can only carry permissions the user holds today
+	scopes := uniqueStrings(req.Scopes)
+	for _, scope := range scopes {
+		if !containsString(permissions, scope) {
+			return nil, "", fmt.Errorf("scope not permitted: %s", scope)
+		}
+	}
+
+	active, err := s.patRepo.CountActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if active >= maxActivePersonalTokens {
+		return nil, "", fmt.Errorf("a user can have at most %d active tokens", maxActivePersonalTokens)
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext := utils.PersonalAccessTokenPrefix + secret
+
+	ttl := defaultPersonalTokenTTL
+	if req.ExpiresInDays != nil {
+		ttl = time.Duration(*req.ExpiresInDays) * 24 * time.Hour
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	token := &models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		TokenPrefix: plaintext[:personalTokenDisplayLen],
+		TokenHash:   utils.HashToken(plaintext),
+		Scopes:      strings.Join(scopes, " "),
+		ExpiresAt:   &expiresAt,
+	}
+	if err := s.patRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	logger.GetLogger().Info("Personal access token created", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "pat_create",
+		"user_id":    userID.String(),
+		"token_id":   token.ID.String(),
+		"scopes":     token.Scopes,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditPersonalTokenCreate,
+		SubjectID: &userID,
+		Metadata:  map[string]interface{}{"token_id": token.ID.String(), "name": token.Name, "scopes": token.Scopes},
+	})
+
+	return token, plaintext, nil
+}
+
+func (s *PersonalAccessTokenServiceImpl) ListTokens(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error) {
+	return s.patRepo.ListByUser(ctx, userID)
+}
+
+func (s *PersonalAccessTokenServiceImpl) RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	token, err := s.patRepo.GetByID(ctx, tokenID)
+	if err != nil || token.UserID != userID {
+		return errors.New("token not found")
+	}
+	return s.revoke(ctx, token)
+}
+
+func (s *PersonalAccessTokenServiceImpl) RevokePlaintext(ctx context.Context, token string) error {
+	if !utils.IsPersonalAccessToken(token) {
+		return nil
+	}
+	pat, err := s.patRepo.GetByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil
+	}
+	return s.revoke(ctx, pat)
+}
+
+func (s *PersonalAccessTokenServiceImpl) revoke(ctx context.Context, token *models.PersonalAccessToken) error {
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.patRepo.Revoke(ctx, token.ID, time.Now()); err != nil {
+		return err
+	}
+
+	logger.GetLogger().Info("Personal access token revoked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "pat_revoke",
+		"user_id":    token.UserID.String(),
+		"token_id":   token.ID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditPersonalTokenRevoke,
+		SubjectID: &token.UserID,
+		Metadata:  map[string]interface{}{"token_id": token.ID.String()},
+	})
+
+	return nil
+}
+
+func (s *PersonalAccessTokenServiceImpl) Authenticate(ctx context.Context, token string) (*utils.UserContext, error) {
+	pat, user, permissions, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) > personalTokenTouchPeriod {
+		if err := s.patRepo.TouchLastUsed(ctx, pat.ID, now); err != nil {
+			logger.GetLogger().Warn("Failed to record token usage", map[string]interface{}{
+				"request_id": contextutil.GetRequestID(ctx),
+				"token_id":   pat.ID.String(),
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	// Roles are deliberately omitted: a token is limited to its scopes,
+	// so role-gated routes are not reachable with it
+	userCtx := &utils.UserContext{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		Roles:       []string{},
+		Permissions: permissions,
+		TokenID:     pat.ID.String(),
+		IssuedAt:    pat.CreatedAt,
+		TokenType:   utils.TokenTypePersonalToken,
+	}
+	if pat.ExpiresAt != nil {
+		userCtx.ExpiresAt = *pat.ExpiresAt
+	}
+
+	return userCtx, nil
+}
+
+func (s *PersonalAccessTokenServiceImpl) Introspect(ctx context.Context, token string) (*dto.IntrospectionResponse, error) {
+	pat, user, permissions, err := s.resolve(ctx, token)
+	if err != nil {
+		return &dto.IntrospectionResponse{Active: false}, nil
+	}
+
+	response := &dto.IntrospectionResponse{
+		Active:      true,
+		Scope:       strings.Join(permissions, " "),
+		Username:    user.Username,
+		TokenType:   utils.TokenTypePersonalToken,
+		Iat:         pat.CreatedAt.Unix(),
+		Sub:         user.ID.String(),
+		Jti:         pat.ID.String(),
+		Email:       user.Email,
+		Permissions: permissions,
+	}
+	if pat.ExpiresAt != nil {
+		response.Exp = pat.ExpiresAt.Unix()
+	}
+
+	return response, nil
+}
+
+// resolve looks up an active token and its owner and returns the effective permissions
+func (s *PersonalAccessTokenServiceImpl) resolve(ctx context.Context, token string) (*models.PersonalAccessToken, *models.User, []string, error) {
+	if !utils.IsPersonalAccessToken(token) {
+		return nil, nil, nil, utils.ErrInvalidToken
+	}
+
+	pat, err := s.patRepo.GetByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, nil, nil, utils.ErrInvalidToken
+	}
+
+	if pat.RevokedAt != nil {
+		return nil, nil, nil, utils.ErrRevokedToken
+	}
+	if !pat.IsActive(time.Now()) {
+		return nil, nil, nil, utils.ErrExpiredToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, pat.UserID)
+	if err != nil || !user.IsActive {
+		return nil, nil, nil, utils.ErrInvalidToken
+	}
+
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, user.ID, time.Now())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if suspension != nil {
+		return nil, nil, nil, utils.ErrRevokedToken
+	}
+
+	// Permissions removed from the user since creation are no longer granted
+	_, granted, err := s.rbacService.ResolveAuthorization(ctx, user)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	permissions := []string{}
+	for _, scope := range pat.ScopeList() {
+		if containsString(granted, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	return pat, user, permissions, nil
+}
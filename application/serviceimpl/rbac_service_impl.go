@@ -0,0 +1,489 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RBACServiceImpl struct {
+	roleRepo       repositories.RoleRepository
+	userRepo       repositories.UserRepository
+	revocationRepo repositories.TokenRevocationRepository
+	auditService   services.AuditService
+	syncService    *SyncService
+}
+
+func NewRBACService(
+	roleRepo repositories.RoleRepository,
+	userRepo repositories.UserRepository,
+	revocationRepo repositories.TokenRevocationRepository,
+	auditService services.AuditService,
+	syncService *SyncService,
+) services.RBACService {
+	return &RBACServiceImpl{
+		roleRepo:       roleRepo,
+		userRepo:       userRepo,
+		revocationRepo: revocationRepo,
+		auditService:   auditService,
+		syncService:    syncService,
+	}
+}
+
+func (s *RBACServiceImpl) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+func (s *RBACServiceImpl) GetRole(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	role, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+	return role, nil
+}
+
+func (s *RBACServiceImpl) CreateRole(ctx context.Context, req *dto.CreateRoleRequest, actorID uuid.UUID) (*models.Role, error) {
+	name := normalizeRoleName(req.Name)
+
+	if existing, _ := s.roleRepo.GetByName(ctx, name); existing != nil {
+		return nil, errors.New("role already exists")
+	}
+
+	permissions, err := s.lookupPermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: req.Description,
+		Permissions: permissions,
+	}
+	// A role is a grant waiting to be assigned, so it may only hold what its creator holds
+	if err := s.CheckGrantable(ctx, actorID, role); err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	logger.GetLogger().Info("Role created", map[string]interface{}{
+		"request_id":  contextutil.GetRequestID(ctx),
+		"action":      "role_create",
+		"role":        role.Name,
+		"permissions": req.Permissions,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditRoleCreated,
+		ActorID:   &actorID,
+		Metadata:  map[string]interface{}{"role": role.Name, "permissions": req.Permissions},
+	})
+
+	return role, nil
+}
+
+func (s *RBACServiceImpl) UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateRoleRequest, actorID uuid.UUID) (*models.Role, error) {
+	role, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+
+	// Validate everything before writing, so a rejected request changes nothing
+	var permissions []models.Permission
+	if req.Permissions != nil {
+		if role.Name == models.RoleAdmin {
+			return nil, errors.New("admin role permissions are managed by the system")
+		}
+
+		permissions, err = s.lookupPermissions(ctx, req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		if permissions == nil {
+			// An empty list removes every permission
+			permissions = []models.Permission{}
+		}
+		if err := s.CheckGrantable(ctx, actorID, &models.Role{Name: role.Name, Permissions: permissions}); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	if err := s.roleRepo.Update(ctx, role, permissions); err != nil {
+		return nil, err
+	}
+
+	if req.Permissions != nil {
+		s.revokeHolderTokens(ctx, role)
+	}
+
+	logger.GetLogger().Info("Role updated", map[string]interface{}{
+		"request_id":  contextutil.GetRequestID(ctx),
+		"action":      "role_update",
+		"role":        role.Name,
+		"permissions": req.Permissions,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditRoleUpdated,
+		ActorID:   &actorID,
+		Metadata:  map[string]interface{}{"role": role.Name, "permissions": req.Permissions},
+	})
+
+	return role, nil
+}
+
+func (s *RBACServiceImpl) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	role, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return errors.New("role not found")
+	}
+
+	if role.IsSystem {
+		return errors.New("system roles cannot be deleted")
+	}
+
+	// Deleting the role removes its assignments, so find its holders first
+	holderIDs, err := s.roleRepo.ListHolderIDs(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.revokeUserTokens(ctx, role, holderIDs)
+
+	logger.GetLogger().Info("Role deleted", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "role_delete",
+		"role":       role.Name,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditRoleDeleted,
+		Metadata:  map[string]interface{}{"role": role.Name},
+	})
+
+	return nil
+}
+
+func (s *RBACServiceImpl) ListPermissions(ctx context.Context) ([]*models.Permission, error) {
+	return s.roleRepo.ListPermissions(ctx)
+}
+
+func (s *RBACServiceImpl) GetUserRoles(ctx context.Context, userID uuid.UUID) (*dto.UserRolesResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	roles, err := s.effectiveRoles(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.UserRolesResponse{
+		UserID:      user.ID,
+		PrimaryRole: user.Role,
+		Roles:       make([]dto.RoleResponse, len(roles)),
+		Permissions: collectPermissions(roles),
+	}
+	for i, role := range roles {
+		response.Roles[i] = *dto.RoleToRoleResponse(role)
+	}
+
+	return response, nil
+}
+
+func (s *RBACServiceImpl) AssignRole(ctx context.Context, userID uuid.UUID, roleName string, assignedBy uuid.UUID) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return errors.New("user not found")
+	}
+
+	role, err := s.roleRepo.GetByName(ctx, normalizeRoleName(roleName))
+	if err != nil {
+		return errors.New("role not found")
+	}
+
+	if err := s.CheckGrantable(ctx, assignedBy, role); err != nil {
+		return err
+	}
+
+	userRole := &models.UserRole{
+		UserID:     userID,
+		RoleID:     role.ID,
+		AssignedBy: &assignedBy,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.roleRepo.AssignToUser(ctx, userRole); err != nil {
+		return err
+	}
+
+	// New permissions are picked up on the user's next sign-in
+	logger.GetLogger().Info("Role assigned", map[string]interface{}{
+		"request_id":  contextutil.GetRequestID(ctx),
+		"action":      "role_assign",
+		"user_id":     userID.String(),
+		"role":        role.Name,
+		"assigned_by": assignedBy.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditRoleAssigned,
+		ActorID:   &assignedBy,
+		SubjectID: &userID,
+		Metadata:  map[string]interface{}{"role": role.Name},
+	})
+
+	return nil
+}
+
+func (s *RBACServiceImpl) RemoveRole(ctx context.Context, userID, roleID uuid.UUID, removedBy uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return errors.New("role not found")
+	}
+
+	// Only a role the remover could have granted may be taken away
+	if err := s.CheckGrantable(ctx, removedBy, role); err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.RemoveFromUser(ctx, userID, roleID); err != nil {
+		return err
+	}
+
+	// The legacy users.role column is an implicit assignment; fall back to the default role
+	if user.Role == role.Name && role.Name != models.RoleUser {
+		previousRole := user.Role
+		user.Role = models.RoleUser
+		user.UpdatedAt = time.Now()
+		if err := s.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{
+			"role":       user.Role,
+			"updated_at": user.UpdatedAt,
+		}, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+			Action:       "role_changed",
+			Role:         user.Role,
+			PreviousRole: previousRole,
+			ActorID:      removedBy.String(),
+		})); err != nil {
+			return err
+		}
+	}
+
+	// Removed permissions must not survive in already-issued tokens
+	if err := s.revocationRepo.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		logger.GetLogger().Warn("Failed to revoke tokens after role removal", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"user_id":    userID.String(),
+			"error":      err.Error(),
+		})
+	}
+
+	logger.GetLogger().Info("Role removed", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "role_remove",
+		"user_id":    userID.String(),
+		"role":       role.Name,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditRoleRemoved,
+		ActorID:   &removedBy,
+		SubjectID: &userID,
+		Metadata:  map[string]interface{}{"role": role.Name},
+	})
+
+	return nil
+}
+
+func (s *RBACServiceImpl) ResolveAuthorization(ctx context.Context, user *models.User) ([]string, []string, error) {
+	roles, err := s.effectiveRoles(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = role.Name
+	}
+
+	return roleNames, collectPermissions(roles), nil
+}
+
+// revokeHolderTokens revokes the tokens of every holder of a role whose permissions changed,
+// since already-issued tokens carry the old permissions
+func (s *RBACServiceImpl) revokeHolderTokens(ctx context.Context, role *models.Role) {
+	holderIDs, err := s.roleRepo.ListHolderIDs(ctx, role)
+	if err != nil {
+		logger.GetLogger().Error("Failed to list role holders for token revocation", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"role":       role.Name,
+			"error":      err.Error(),
+		})
+		return
+	}
+	s.revokeUserTokens(ctx, role, holderIDs)
+}
+
+func (s *RBACServiceImpl) revokeUserTokens(ctx context.Context, role *models.Role, userIDs []uuid.UUID) {
+	now := time.Now()
+	for _, userID := range userIDs {
+		if err := s.revocationRepo.RevokeUserTokens(ctx, userID, now); err != nil {
+			logger.GetLogger().Warn("Failed to revoke tokens after role change", map[string]interface{}{
+				"request_id": contextutil.GetRequestID(ctx),
+				"user_id":    userID.String(),
+				"role":       role.Name,
+				"error":      err.Error(),
+			})
+		}
+	}
+}
+
+// CheckGrantable keeps administrators from handing out more than they hold themselves
+func (s *RBACServiceImpl) CheckGrantable(ctx context.Context, grantorID uuid.UUID, role *models.Role) error {
+	held, err := s.heldPermissions(ctx, grantorID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range role.Permissions {
+		if !containsString(held, permission.Name) {
+			return fmt.Errorf("cannot grant role %s: it includes %s, which you do not hold", role.Name, permission.Name)
+		}
+	}
+
+	return nil
+}
+
+func (s *RBACServiceImpl) CheckManageable(ctx context.Context, actorID uuid.UUID, target *models.User) error {
+	held, err := s.heldPermissions(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	_, targetPermissions, err := s.ResolveAuthorization(ctx, target)
+	if err != nil {
+		return err
+	}
+	for _, permission := range targetPermissions {
+		if !containsString(held, permission) {
+			return fmt.Errorf("cannot manage user %s: they hold %s, which you do not hold", target.Username, permission)
+		}
+	}
+
+	return nil
+}
+
+// heldPermissions returns the effective permissions of the acting user
+func (s *RBACServiceImpl) heldPermissions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("acting user not found")
+	}
+	_, held, err := s.ResolveAuthorization(ctx, user)
+	return held, err
+}
+
+// effectiveRoles returns assigned roles plus the legacy primary role
+func (s *RBACServiceImpl) effectiveRoles(ctx context.Context, user *models.User) ([]*models.Role, error) {
+	roles, err := s.roleRepo.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "" {
+		for _, role := range roles {
+			if role.Name == user.Role {
+				return roles, nil
+			}
+		}
+		if primary, err := s.roleRepo.GetByName(ctx, user.Role); err == nil {
+			roles = append(roles, primary)
+		}
+	}
+
+	return roles, nil
+}
+
+// lookupPermissions resolves permission names and rejects unknown ones
+func (s *RBACServiceImpl) lookupPermissions(ctx context.Context, names []string) ([]models.Permission, error) {
+	permissions, err := s.roleRepo.GetPermissionsByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissions) != len(uniqueStrings(names)) {
+		found := make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			found[permission.Name] = true
+		}
+		for _, name := range names {
+			if !found[name] {
+				return nil, fmt.Errorf("unknown permission: %s", name)
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+// collectPermissions returns the sorted union of the roles' permissions
+func collectPermissions(roles []*models.Role) []string {
+	set := make(map[string]bool)
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			set[permission.Name] = true
+		}
+	}
+
+	permissions := make([]string, 0, len(set))
+	for name := range set {
+		permissions = append(permissions, name)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+func normalizeRoleName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
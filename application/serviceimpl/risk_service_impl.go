@@ -0,0 +1,281 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/metrics"
+	"gofiber-template/pkg/risk"
+	"gofiber-template/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scores of the built-in risk signals; the thresholds they are compared with are configurable
+const (
+	riskScoreNewIP            = 20
+	riskScoreImpossibleTravel = 60
+	riskScoreFailedAttempts   = 20 // Doubled at twice the failure threshold
+	riskScoreTorExit          = 70
+	riskScoreDatacenter       = 30
+
+	maxChallengeFailures = 5
+)
+
+var errInvalidChallenge = errors.New("verification code is invalid or has expired")
+
+type RiskServiceImpl struct {
+	engine        *risk.Engine
+	userRepo      repositories.UserRepository
+	challengeRepo repositories.LoginChallengeRepository
+	auditService  services.AuditService
+	emailSender   services.EmailSender
+	config        *config.Config
+}
+
+func NewRiskService(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	attemptRepo repositories.LoginAttemptRepository,
+	challengeRepo repositories.LoginChallengeRepository,
+	auditService services.AuditService,
+	emailSender services.EmailSender,
+	cfg *config.Config,
+) services.RiskService {
+	history := &sessionHistory{sessionRepo: sessionRepo}
+	evaluators := []risk.Evaluator{
+		risk.NewIPEvaluator(history, riskScoreNewIP),
+		risk.NewFailureVelocityEvaluator(&attemptCounter{attemptRepo: attemptRepo}, cfg.Risk.FailureThreshold, riskScoreFailedAttempts),
+	}
+
+	if geo, err := risk.LoadGeoIP(cfg.Risk.GeoIPFile); err != nil {
+		logRiskDataError("GeoIP database", err)
+	} else if geo != nil {
+		evaluators = append(evaluators, risk.NewImpossibleTravelEvaluator(history, geo, cfg.Risk.MaxTravelSpeedKmh, riskScoreImpossibleTravel))
+	}
+	if tor, err := risk.LoadIPList(cfg.Risk.TorListFile); err != nil {
+		logRiskDataError("Tor exit list", err)
+	} else if tor.Len() > 0 {
+		evaluators = append(evaluators, risk.NewListedIPEvaluator("tor_exit", tor, riskScoreTorExit))
+	}
+	if datacenters, err := risk.LoadIPList(cfg.Risk.DatacenterListFile); err != nil {
+		logRiskDataError("Datacenter IP list", err)
+	} else if datacenters.Len() > 0 {
+		evaluators = append(evaluators, risk.NewListedIPEvaluator("datacenter_ip", datacenters, riskScoreDatacenter))
+	}
+
+	return &RiskServiceImpl{
+		engine: risk.NewEngine(risk.Thresholds{
+			StepUp: cfg.Risk.StepUpScore,
+			Deny:   cfg.Risk.DenyScore,
+		}, evaluators...),
+		userRepo:      userRepo,
+		challengeRepo: challengeRepo,
+		auditService:  auditService,
+		emailSender:   emailSender,
+		config:        cfg,
+	}
+}
+
+func (s *RiskServiceImpl) CheckLogin(ctx context.Context, user *models.User, method string) error {
+	if !s.config.Risk.Enabled {
+		return nil
+	}
+
+	assessment, err := s.engine.Assess(ctx, &risk.Input{
+		UserID:    user.ID,
+		Email:     user.Email,
+		IPAddress: contextutil.GetClientIP(ctx),
+		UserAgent: contextutil.GetUserAgent(ctx),
+		Method:    method,
+		Time:      time.Now(),
+	})
+	if err != nil {
+		logger.GetLogger().Warn("Sign-in risk signal unavailable", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"action":     "login_risk",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+
+	s.recordAssessment(ctx, user, method, assessment)
+
+	switch assessment.Decision {
+	case risk.DecisionDeny:
+		return services.ErrLoginDenied
+	case risk.DecisionStepUp:
+		return s.startChallenge(ctx, user, method)
+	}
+	return nil
+}
+
+func (s *RiskServiceImpl) VerifyChallenge(ctx context.Context, challengeID, code string) (*models.User, string, error) {
+	key := utils.HashToken(challengeID)
+
+	challenge, err := s.challengeRepo.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	if challenge == nil || !time.Now().Before(challenge.ExpiresAt) {
+		return nil, "", errInvalidChallenge
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(code)), []byte(challenge.CodeHash)) != 1 {
+		failures, err := s.challengeRepo.RecordFailure(ctx, key)
+		if err == nil && failures >= maxChallengeFailures {
+			// Guessing is over; the user has to sign in again for a new code
+			s.challengeRepo.Delete(ctx, key)
+		}
+		return nil, "", errInvalidChallenge
+	}
+
+	consumed, err := s.challengeRepo.Delete(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	if !consumed {
+		return nil, "", errInvalidChallenge
+	}
+
+	user, err := s.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, "", errInvalidChallenge
+	}
+	return user, challenge.Method, nil
+}
+
+// startChallenge emails a one-time code and returns the error telling the client to ask for it
+func (s *RiskServiceImpl) startChallenge(ctx context.Context, user *models.User, method string) error {
+	challengeID, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	code, err := utils.GenerateNumericCode(6)
+	if err != nil {
+		return err
+	}
+
+	ttl := s.config.Risk.ChallengeTTL
+	if err := s.challengeRepo.Create(ctx, utils.HashToken(challengeID), &repositories.LoginChallenge{
+		UserID:    user.ID,
+		Method:    method,
+		CodeHash:  utils.HashToken(code),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	message := &services.EmailMessage{
+		To:      user.Email,
+		Subject: "Your sign-in verification code",
+		Body: fmt.Sprintf(
+			"We need to confirm it is you signing in.\n\nVerification code: %s\n\nThe code expires in %s. If you did not try to sign in, change your password.",
+			code, ttl,
+		),
+	}
+	if err := s.emailSender.Send(ctx, message); err != nil {
+		logger.GetLogger().Error("Failed to send sign-in verification code", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"action":     "login_risk",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+		return err
+	}
+
+	return &services.StepUpRequiredError{
+		ChallengeID: challengeID,
+		Method:      "email_otp",
+		ExpiresIn:   ttl,
+	}
+}
+
+func (s *RiskServiceImpl) recordAssessment(ctx context.Context, user *models.User, method string, assessment *risk.Assessment) {
+	metrics.LoginRiskDecisionsTotal.WithLabelValues(method, string(assessment.Decision)).Inc()
+
+	if assessment.Decision != risk.DecisionAllow {
+		logger.GetLogger().Warn("Risky sign-in", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"action":     "login_risk",
+			"user_id":    user.ID.String(),
+			"score":      assessment.Score,
+			"decision":   string(assessment.Decision),
+		})
+	}
+
+	signals := make([]map[string]interface{}, len(assessment.Signals))
+	for i, signal := range assessment.Signals {
+		signals[i] = map[string]interface{}{"name": signal.Name, "score": signal.Score}
+		if signal.Detail != "" {
+			signals[i]["detail"] = signal.Detail
+		}
+	}
+
+	event := &models.AuditEvent{
+		EventType: models.AuditLoginRiskAssessed,
+		SubjectID: &user.ID,
+		Metadata: map[string]interface{}{
+			"method":   method,
+			"score":    assessment.Score,
+			"decision": string(assessment.Decision),
+			"signals":  signals,
+		},
+	}
+	if assessment.Decision == risk.DecisionDeny {
+		event.Outcome = models.AuditOutcomeFailure
+	}
+	s.auditService.Record(ctx, event)
+}
+
+func logRiskDataError(source string, err error) {
+	logger.GetLogger().Warn(source+" not loaded", map[string]interface{}{
+		"action": "login_risk",
+		"error":  err.Error(),
+	})
+}
+
+// sessionHistory answers risk questions from the sign-in sessions table
+type sessionHistory struct {
+	sessionRepo repositories.SessionRepository
+}
+
+func (h *sessionHistory) SeenIP(ctx context.Context, userID uuid.UUID, ip string) (bool, error) {
+	return h.sessionRepo.HasIPAddress(ctx, userID, ip)
+}
+
+func (h *sessionHistory) LastSighting(ctx context.Context, userID uuid.UUID) (*risk.Sighting, error) {
+	session, err := h.sessionRepo.GetLatestByUser(ctx, userID)
+	if err != nil || session == nil {
+		return nil, err
+	}
+	return &risk.Sighting{IPAddress: session.IPAddress, At: session.LastSeenAt}, nil
+}
+
+// attemptCounter reads the failed sign-in counters kept by the lockout service
+type attemptCounter struct {
+	attemptRepo repositories.LoginAttemptRepository
+}
+
+func (c *attemptCounter) RecentFailures(ctx context.Context, in *risk.Input) (int64, int64, error) {
+	account, err := c.attemptRepo.GetState(ctx, accountAttemptKey(in.Email))
+	if err != nil {
+		return 0, 0, err
+	}
+	if in.IPAddress == "" {
+		return account.Failures, 0, nil
+	}
+	ip, err := c.attemptRepo.GetState(ctx, ipAttemptKey(in.IPAddress))
+	if err != nil {
+		return 0, 0, err
+	}
+	return account.Failures, ip.Failures, nil
+}
@@ -0,0 +1,193 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sessionTouchInterval bounds how often last-seen is written for one session
+const sessionTouchInterval = 5 * time.Minute
+
+type SessionServiceImpl struct {
+	sessionRepo    repositories.SessionRepository
+	revocationRepo repositories.TokenRevocationRepository
+	userRepo       repositories.UserRepository
+	auditService   services.AuditService
+	syncService    *SyncService
+
+	// lastTouched remembers recent writes so most requests skip the database
+	lastTouched sync.Map // uuid.UUID -> time.Time
+}
+
+func NewSessionService(
+	sessionRepo repositories.SessionRepository,
+	revocationRepo repositories.TokenRevocationRepository,
+	userRepo repositories.UserRepository,
+	auditService services.AuditService,
+	syncService *SyncService,
+) services.SessionService {
+	return &SessionServiceImpl{
+		sessionRepo:    sessionRepo,
+		revocationRepo: revocationRepo,
+		userRepo:       userRepo,
+		auditService:   auditService,
+		syncService:    syncService,
+	}
+}
+
+func (s *SessionServiceImpl) Start(ctx context.Context, userID uuid.UUID, expiresAt time.Time) (*models.UserSession, error) {
+	now := time.Now()
+	userAgent := contextutil.GetUserAgent(ctx)
+
+	session := &models.UserSession{
+		ID:         uuid.New(),
+		UserID:     userID,
+		DeviceName: utils.DeviceName(userAgent),
+		UserAgent:  truncate(userAgent, 500),
+		IPAddress:  contextutil.GetClientIP(ctx),
+		Location:   truncate(contextutil.GetClientLocation(ctx), 100),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	s.lastTouched.Store(session.ID, now)
+	return session, nil
+}
+
+func (s *SessionServiceImpl) Resume(ctx context.Context, userID, sessionID uuid.UUID, expiresAt time.Time) error {
+	now := time.Now()
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return utils.ErrInvalidToken
+	}
+	if !session.IsActive(now) {
+		return utils.ErrRevokedToken
+	}
+
+	if err := s.sessionRepo.Extend(ctx, sessionID, now, expiresAt); err != nil {
+		return err
+	}
+	s.lastTouched.Store(sessionID, now)
+	return nil
+}
+
+func (s *SessionServiceImpl) IsRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	revoked, err := s.revocationRepo.IsSessionRevoked(ctx, sessionID.String())
+	if err != nil {
+		logger.GetLogger().Error("Session revocation lookup failed", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"session_id": sessionID.String(),
+			"error":      err.Error(),
+		})
+		return false, utils.ErrRevocationUnavailable
+	}
+	return revoked, nil
+}
+
+func (s *SessionServiceImpl) Touch(ctx context.Context, sessionID uuid.UUID) {
+	now := time.Now()
+	if last, ok := s.lastTouched.Load(sessionID); ok && now.Sub(last.(time.Time)) < sessionTouchInterval {
+		return
+	}
+	s.lastTouched.Store(sessionID, now)
+
+	// The conditional update also keeps other instances from writing more than once per interval
+	err := s.sessionRepo.Touch(ctx, sessionID, now, now.Add(-sessionTouchInterval), contextutil.GetClientIP(ctx))
+	if err != nil {
+		logger.GetLogger().Warn("Failed to record session activity", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"session_id": sessionID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (s *SessionServiceImpl) ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error) {
+	return s.sessionRepo.ListActiveByUser(ctx, userID, time.Now())
+}
+
+func (s *SessionServiceImpl) Revoke(ctx context.Context, userID, sessionID uuid.UUID, reason string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return errors.New("session not found")
+	}
+
+	now := time.Now()
+	revoked, err := s.sessionRepo.Revoke(ctx, sessionID, now, reason)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("session already revoked")
+	}
+
+	// Access tokens are checked against Redis on every request
+	if err := s.revocationRepo.RevokeSession(ctx, sessionID.String(), session.ExpiresAt); err != nil {
+		logger.GetLogger().Warn("Failed to store session revocation", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"session_id": sessionID.String(),
+			"error":      err.Error(),
+		})
+	}
+	s.lastTouched.Delete(sessionID)
+
+	logger.GetLogger().Info("Session revoked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "session_revoke",
+		"user_id":    userID.String(),
+		"session_id": sessionID.String(),
+		"reason":     reason,
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditSessionRevoked,
+		SubjectID: &userID,
+		Metadata: map[string]interface{}{
+			"session_id": sessionID.String(),
+			"device":     session.DeviceName,
+			"reason":     reason,
+		},
+	})
+
+	s.publishRevoked(ctx, userID, sessionID.String(), reason)
+	return nil
+}
+
+func (s *SessionServiceImpl) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := s.sessionRepo.RevokeAllForUser(ctx, userID, time.Now(), models.SessionRevokedAll); err != nil {
+		return err
+	}
+
+	s.publishRevoked(ctx, userID, "", models.SessionRevokedAll)
+	return nil
+}
+
+// publishRevoked publishes user.session_revoked; sessionID is empty when all sessions were revoked
+func (s *SessionServiceImpl) publishRevoked(ctx context.Context, userID uuid.UUID, sessionID, reason string) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		// Sessions of a deleted user are revoked after user.deleted was published
+		return
+	}
+
+	s.syncService.SyncUserEventAsync(ctx, user, services.UserEventData{
+		Action:    "session_revoked",
+		SessionID: sessionID,
+		Reason:    reason,
+	})
+}
@@ -0,0 +1,215 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// liftExpiredBatchSize bounds the work done by one scheduler run
+const liftExpiredBatchSize = 100
+
+type SuspensionServiceImpl struct {
+	suspensionRepo repositories.SuspensionRepository
+	userRepo       repositories.UserRepository
+	tokenService   services.TokenService
+	auditService   services.AuditService
+	syncService    *SyncService
+}
+
+func NewSuspensionService(
+	suspensionRepo repositories.SuspensionRepository,
+	userRepo repositories.UserRepository,
+	tokenService services.TokenService,
+	auditService services.AuditService,
+	syncService *SyncService,
+) services.SuspensionService {
+	return &SuspensionServiceImpl{
+		suspensionRepo: suspensionRepo,
+		userRepo:       userRepo,
+		tokenService:   tokenService,
+		auditService:   auditService,
+		syncService:    syncService,
+	}
+}
+
+func (s *SuspensionServiceImpl) Suspend(ctx context.Context, actorID, userID uuid.UUID, req *dto.SuspendUserRequest) (*models.UserSuspension, error) {
+	if actorID == userID {
+		return nil, errSelfModification
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	now := time.Now()
+	if req.EndsAt != nil && !req.EndsAt.After(now) {
+		return nil, errors.New("suspension end time must be in the future")
+	}
+
+	existing, err := s.suspensionRepo.GetActiveByUser(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user is already suspended")
+	}
+
+	suspension := &models.UserSuspension{
+		UserID:      userID,
+		Reason:      strings.TrimSpace(req.Reason),
+		Notes:       req.Notes,
+		SuspendedBy: actorID,
+		StartsAt:    now,
+		EndsAt:      req.EndsAt,
+	}
+	event := s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action:  "suspended",
+		EndsAt:  suspension.EndsAt,
+		ActorID: actorID.String(),
+	})
+	if err := s.suspensionRepo.Create(ctx, suspension, event); err != nil {
+		return nil, err
+	}
+
+	// Ends the user's sessions; tokens are refused while suspended even if this fails
+	if err := s.tokenService.RevokeAllForUser(ctx, userID); err != nil {
+		logger.GetLogger().Warn("Failed to revoke tokens of suspended user", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"user_id":    userID.String(),
+			"error":      err.Error(),
+		})
+	}
+
+	fields := map[string]interface{}{
+		"request_id":    contextutil.GetRequestID(ctx),
+		"action":        "user_suspend",
+		"actor_id":      actorID.String(),
+		"user_id":       userID.String(),
+		"suspension_id": suspension.ID.String(),
+	}
+	if suspension.EndsAt != nil {
+		fields["ends_at"] = suspension.EndsAt.UTC().Format(time.RFC3339)
+	}
+	logger.GetLogger().Info("User suspended", fields)
+
+	metadata := map[string]interface{}{"suspension_id": suspension.ID.String(), "reason": suspension.Reason}
+	if suspension.EndsAt != nil {
+		metadata["ends_at"] = suspension.EndsAt.UTC().Format(time.RFC3339)
+	}
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditUserSuspended,
+		ActorID:   &actorID,
+		SubjectID: &userID,
+		Metadata:  metadata,
+	})
+
+	return suspension, nil
+}
+
+func (s *SuspensionServiceImpl) Lift(ctx context.Context, actorID, userID uuid.UUID, reason string) error {
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if suspension == nil {
+		return errors.New("user is not suspended")
+	}
+
+	return s.lift(ctx, suspension, &actorID, reason)
+}
+
+func (s *SuspensionServiceImpl) ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSuspension, error) {
+	return s.suspensionRepo.ListByUser(ctx, userID)
+}
+
+func (s *SuspensionServiceImpl) CheckSignIn(ctx context.Context, userID uuid.UUID) error {
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if suspension == nil {
+		return nil
+	}
+
+	if suspension.EndsAt == nil {
+		return fmt.Errorf("account is suspended: %s", suspension.Reason)
+	}
+	return fmt.Errorf("account is suspended until %s: %s", suspension.EndsAt.UTC().Format(time.RFC3339), suspension.Reason)
+}
+
+func (s *SuspensionServiceImpl) LiftExpired(ctx context.Context) (int, error) {
+	expired, err := s.suspensionRepo.ListExpired(ctx, time.Now(), liftExpiredBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	lifted := 0
+	for _, suspension := range expired {
+		if err := s.lift(ctx, suspension, nil, "suspension expired"); err != nil {
+			logger.GetLogger().Error("Failed to lift expired suspension", map[string]interface{}{
+				"action":        "user_reinstate",
+				"suspension_id": suspension.ID.String(),
+				"error":         err.Error(),
+			})
+			continue
+		}
+		lifted++
+	}
+
+	return lifted, nil
+}
+
+// lift ends a suspension and publishes user.reinstated; liftedBy is nil for automatic expiry
+func (s *SuspensionServiceImpl) lift(ctx context.Context, suspension *models.UserSuspension, liftedBy *uuid.UUID, reason string) error {
+	// A user deleted while suspended has nobody to reinstate downstream, so no event is written
+	var events []*models.OutboxEvent
+	if user, err := s.userRepo.GetByID(ctx, suspension.UserID); err == nil {
+		data := services.UserEventData{Action: "reinstated"}
+		if liftedBy != nil {
+			data.ActorID = liftedBy.String()
+		}
+		events = append(events, s.syncService.UserEventWith(ctx, user, data))
+	}
+
+	ok, err := s.suspensionRepo.Lift(ctx, suspension.ID, time.Now(), liftedBy, reason, events...)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Lifted concurrently by an administrator or another scheduler run
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"request_id":    contextutil.GetRequestID(ctx),
+		"action":        "user_reinstate",
+		"user_id":       suspension.UserID.String(),
+		"suspension_id": suspension.ID.String(),
+	}
+	if liftedBy != nil {
+		fields["actor_id"] = liftedBy.String()
+	}
+	logger.GetLogger().Info("User reinstated", fields)
+
+	// Automatic reinstatement has no actor
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditUserReinstated,
+		ActorID:   liftedBy,
+		SubjectID: &suspension.UserID,
+		Metadata:  map[string]interface{}{"suspension_id": suspension.ID.String(), "reason": reason},
+	})
+
+	return nil
+}
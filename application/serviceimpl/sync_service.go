@@ -1,200 +1,303 @@
 package serviceimpl
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"time"
 
+	"github.com/google/uuid"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/services"
+	"gofiber-template/pkg/cloudevents"
 	"gofiber-template/pkg/contextutil"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/workerpool"
 )
 
 // SyncService handles user synchronization to backend
-// Supports both Event-Driven (NATS) and HTTP sync with fallback
+// Events go to NATS and to the subscribed webhook endpoints
 type SyncService struct {
-	eventPublisher services.EventPublisher
-	backendURL     string
-	httpClient     *http.Client
-	useEvents      bool // Feature flag
+	events      *EventEmitter
+	useEvents   bool // Feature flag
+	deadLetters services.DeadLetterService
+	workers     *workerpool.Pool
 }
 
-// NewSyncService creates a new SyncService with optional EventPublisher
-func NewSyncServiceWithPublisher(eventPublisher services.EventPublisher) *SyncService {
+// Retries of SyncUserAsync: delays start at syncRetryBase and double per attempt
+const (
+	syncMaxAttempts = 3
+	syncRetryBase   = time.Second
+)
+
+// NewSyncService creates a new SyncService publishing through the EventEmitter.
+// Asynchronous syncs run on workers; events that cannot be delivered after all
+// retries are kept in the dead-letter queue.
+func NewSyncServiceWithPublisher(events *EventEmitter, deadLetters services.DeadLetterService, workers *workerpool.Pool) *SyncService {
 	useEvents := os.Getenv("USE_EVENT_SYNC") != "false" // Default: true if publisher available
+	if os.Getenv("BACKEND_SYNC_URL") != "" {
+		log.Println("⚠️  BACKEND_SYNC_URL is no longer used; register the URL as a webhook endpoint instead")
+	}
 
 	return &SyncService{
-		eventPublisher: eventPublisher,
-		backendURL:     os.Getenv("BACKEND_SYNC_URL"),
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		useEvents: useEvents && eventPublisher != nil,
+		events:      events,
+		deadLetters: deadLetters,
+		workers:     workers,
+		useEvents:   useEvents && events.Enabled(),
 	}
 }
 
-// UserSyncPayload represents minimal identity event payload
-// Auth Service sends only essential identity information.
-// Downstream services are responsible for enriching user profiles.
-type UserSyncPayload struct {
-	// Minimal Identity Data (Primary Key + Identifiers)
-	ID       string `json:"id"`       // User ID (Primary Key)
-	Email    string `json:"email"`    // Email address (identifier)
-	Username string `json:"username"` // Username (identifier)
-	Action   string `json:"action"`   // "created" | "updated" | "deleted"
-
-	// Observability Metadata
-	RequestID   string `json:"request_id"`   // Correlation ID for distributed tracing
-	Timestamp   string `json:"timestamp"`    // ISO 8601 timestamp
-	ServiceName string `json:"service_name"` // Source service ("gofiber-auth")
-}
-
-// Note: Removed fields (managed by downstream services):
+// Note: User events carry minimal identity data (services.UserEventData).
+// Removed fields (managed by downstream services):
 // - displayName, avatar, bio → Social/Profile Service
 // - role, isActive, permissions → Auth Service internal only
 
-// SyncUser synchronizes user data using Events or HTTP (with fallback)
+// SyncUser synchronizes user data using Events and webhooks
 func (s *SyncService) SyncUser(ctx context.Context, user *models.User, action string) error {
-	requestID := contextutil.GetRequestID(ctx)
-	log := logger.GetLogger()
+	event, err := s.userEvent(ctx, user, services.UserEventData{Action: action})
+	if err != nil {
+		return err
+	}
+	return s.deliver(event)
+}
+
+// UserEvent builds the outbox event for a user change. Pass it to the UserRepository write
+// so that it is stored in the same transaction; the outbox relay delivers it afterwards.
+func (s *SyncService) UserEvent(ctx context.Context, user *models.User, action string) *models.OutboxEvent {
+	return s.UserEventWith(ctx, user, services.UserEventData{Action: action})
+}
 
-	payload := UserSyncPayload{
-		// Minimal Identity Data
-		ID:       user.ID.String(),
-		Email:    user.Email,
-		Username: user.Username,
-		Action:   action,
+// UserEventWith is UserEvent carrying the optional fields set in data; data.Action selects
+// the event type and the identity fields are taken from user
+func (s *SyncService) UserEventWith(ctx context.Context, user *models.User, data services.UserEventData) *models.OutboxEvent {
+	// Only registered actions are passed in, so building the event cannot fail
+	event, _ := s.userEvent(ctx, user, data)
+	payload, _ := json.Marshal(event)
 
-		// Observability Metadata
-		RequestID:   requestID,
-		Timestamp:   time.Now().UTC().Format(time.RFC3339),
-		ServiceName: "gofiber-auth",
+	return &models.OutboxEvent{
+		ID:          uuid.MustParse(event.ID),
+		AggregateID: user.ID,
+		Topic:       data.Action,
+		Payload:     payload,
 	}
+}
 
-	log.Debug("Synchronizing user", map[string]interface{}{
-		"request_id": requestID,
-		"user_id":    user.ID.String(),
-		"action":     action,
-		"method":     getMethod(s.useEvents),
-	})
+// MembershipEvent builds the outbox event for a change of the user's organization membership;
+// data.Action selects the event type. Pass it to the OrganizationRepository write so that it is
+// stored in the same transaction. Events are ordered together with the user's own events.
+func (s *SyncService) MembershipEvent(ctx context.Context, userID uuid.UUID, data services.MembershipEventData) *models.OutboxEvent {
+	data.UserID = userID.String()
 
-	// Strategy 1: Try Event Publishing (if enabled)
-	if s.useEvents {
-		err := s.syncViaEvent(&payload)
-		if err != nil {
-			log.Warn("Event sync failed, falling back to HTTP", map[string]interface{}{
-				"request_id": requestID,
-				"user_id":    user.ID.String(),
-				"action":     action,
-				"error":      err.Error(),
-			})
-			// Fallback to HTTP
-			return s.syncViaHTTP(&payload)
-		}
-		return nil
-	}
+	// Only registered actions are passed in, so building the event cannot fail
+	event, _ := s.events.NewEvent(ctx, "membership."+data.Action, data.UserID, &data)
+	payload, _ := json.Marshal(event)
 
-	// Strategy 2: HTTP Sync (legacy)
-	return s.syncViaHTTP(&payload)
+	return &models.OutboxEvent{
+		ID:          uuid.MustParse(event.ID),
+		AggregateID: userID,
+		Topic:       "membership." + data.Action,
+		Payload:     payload,
+	}
 }
 
-// syncViaEvent publishes user event to NATS
-func (s *SyncService) syncViaEvent(payload *UserSyncPayload) error {
-	if s.eventPublisher == nil {
-		return fmt.Errorf("event publisher not available")
+// PublishOutboxEvent delivers a stored outbox event the same way SyncUser does
+func (s *SyncService) PublishOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	stored, err := cloudevents.Parse(event.Payload)
+	if err != nil {
+		// Events stored before the CloudEvents envelope hold the flat user payload
+		if stored, err = s.upgradeLegacyPayload(event.Payload); err != nil {
+			return fmt.Errorf("invalid outbox payload: %w", err)
+		}
 	}
+	return s.deliver(stored)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// upgradeLegacyPayload wraps a flat user payload (event_id, request_id, timestamp plus the
+// UserEventData fields) in an event, keeping its ID
+func (s *SyncService) upgradeLegacyPayload(raw []byte) (*cloudevents.Event, error) {
+	var legacy struct {
+		services.UserEventData
+		EventID   string `json:"event_id"`
+		RequestID string `json:"request_id"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(raw, &legacy); err != nil {
+		return nil, err
+	}
+	if legacy.EventID == "" || legacy.ID == "" {
+		return nil, errors.New("not a user event payload")
+	}
 
-	// Publish to NATS JetStream
-	// Topic format: user.events.{action} → user.events.created, user.events.updated, etc.
-	topic := payload.Action
-	err := s.eventPublisher.Publish(ctx, topic, payload)
+	ctx := contextutil.WithRequestID(context.Background(), legacy.RequestID)
+	event, err := s.events.NewEvent(ctx, "user."+legacy.Action, legacy.ID, legacy.UserEventData)
 	if err != nil {
-		return fmt.Errorf("failed to publish event: %w", err)
+		return nil, err
+	}
+	event.ID = legacy.EventID
+	if at, err := time.Parse(time.RFC3339, legacy.Timestamp); err == nil {
+		event.Time = at
 	}
+	return event, nil
+}
 
-	log.Printf("✅ User %s synced via Events (action: %s)", payload.Username, payload.Action)
-	return nil
+// userEvent builds the user.<data.Action> event
+func (s *SyncService) userEvent(ctx context.Context, user *models.User, data services.UserEventData) (*cloudevents.Event, error) {
+	data.ID = user.ID.String()
+	data.Email = user.Email
+	data.Username = user.Username
+	return s.events.NewEvent(ctx, "user."+data.Action, user.ID.String(), data)
 }
 
-// syncViaHTTP syncs user via HTTP POST (legacy method)
-func (s *SyncService) syncViaHTTP(payload *UserSyncPayload) error {
-	if s.backendURL == "" {
-		log.Println("⚠️  BACKEND_SYNC_URL not configured, skipping HTTP sync")
-		return nil
-	}
+// deliver sends the event to NATS and queues it for webhooks, or only queues it for webhooks
+// when event sync is off
+func (s *SyncService) deliver(event *cloudevents.Event) error {
+	logger.GetLogger().Debug("Synchronizing user", map[string]interface{}{
+		"request_id": event.RequestID,
+		"event_id":   event.ID,
+		"user_id":    event.Subject,
+		"type":       event.Type,
+		"method":     getMethod(s.useEvents),
+	})
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if !s.useEvents {
+		return s.events.EnqueueWebhooks(ctx, event)
 	}
 
-	req, err := http.NewRequest("POST", s.backendURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	// Subjects: user.events.v{N}.{action} (CloudEvents) and user.events.{action} (legacy payload)
+	if err := s.events.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	log.Printf("✅ User %s synced via Events (type: %s)", event.Subject, event.Type)
+	return nil
+}
+
+// SyncUserAsync queues the user sync on the background worker pool and returns immediately.
+// Only the request ID is carried over from ctx, since request contexts are recycled once the
+// handler returns. Failed deliveries are retried with exponential backoff, then dead-lettered.
+func (s *SyncService) SyncUserAsync(ctx context.Context, user *models.User, action string) {
+	s.SyncUserEventAsync(ctx, user, services.UserEventData{Action: action})
+}
 
-	resp, err := s.httpClient.Do(req)
+// SyncUserEventAsync is SyncUserAsync carrying the optional fields set in data, for events
+// that are not tied to a write of the user record
+func (s *SyncService) SyncUserEventAsync(ctx context.Context, user *models.User, data services.UserEventData) {
+	// Every attempt carries the same event ID so consumers can deduplicate
+	event, err := s.userEvent(ctx, user, data)
 	if err != nil {
-		log.Printf("❌ Failed to sync user %s to backend via HTTP: %v", payload.Username, err)
-		return err
+		logger.GetLogger().Error("Failed to build user event", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"user_id":    user.ID.String(),
+			"action":     data.Action,
+			"error":      err.Error(),
+		})
+		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		log.Printf("❌ Backend HTTP sync failed for user %s: HTTP %d", payload.Username, resp.StatusCode)
-		return fmt.Errorf("backend returned status %d", resp.StatusCode)
+	err = s.workers.Submit(func(jobCtx context.Context) error {
+		return s.syncWithRetry(contextutil.WithRequestID(jobCtx, event.RequestID), event)
+	})
+	if err != nil {
+		// Shutting down or overloaded; keep the event so it can be retried later
+		s.deadLetter(event, 0, err)
 	}
-
-	log.Printf("✅ User %s synced via HTTP (action: %s)", payload.Username, payload.Action)
-	return nil
 }
 
-// SyncUserWithRetry tries to sync with exponential backoff
-func (s *SyncService) SyncUserWithRetry(ctx context.Context, user *models.User, action string) {
-	maxRetries := 3
-	baseDelay := 1 * time.Second
-	requestID := contextutil.GetRequestID(ctx)
+// syncWithRetry delivers the event, backing off between attempts until ctx is cancelled.
+// Once ctx is cancelled the event is dead-lettered without another delivery attempt.
+func (s *SyncService) syncWithRetry(ctx context.Context, event *cloudevents.Event) error {
 	log := logger.GetLogger()
 
-	for i := 0; i < maxRetries; i++ {
-		err := s.SyncUser(ctx, user, action)
+	var err error
+	attempts := 0
+	for attempts < syncMaxAttempts {
+		if ctx.Err() != nil {
+			// Shutdown ran out of time; dead-letter queued jobs without waiting on delivery
+			if err == nil {
+				err = fmt.Errorf("delivery skipped by shutdown: %w", ctx.Err())
+			}
+			break
+		}
+		attempts++
+		err = s.deliver(event)
 		if err == nil {
-			return // Success
+			return nil // Success
+		}
+		if attempts == syncMaxAttempts {
+			break
 		}
 
-		if i < maxRetries-1 {
-			delay := baseDelay * time.Duration(1<<uint(i))
-			log.Warn("Retrying sync", map[string]interface{}{
-				"request_id": requestID,
-				"user_id":    user.ID.String(),
-				"action":     action,
-				"attempt":    i + 2,
-				"max":        maxRetries,
-				"delay_ms":   delay.Milliseconds(),
-			})
-			time.Sleep(delay)
+		delay := syncRetryBase * time.Duration(1<<uint(attempts-1))
+		log.Warn("Retrying sync", map[string]interface{}{
+			"request_id": event.RequestID,
+			"event_id":   event.ID,
+			"user_id":    event.Subject,
+			"type":       event.Type,
+			"attempt":    attempts + 1,
+			"max":        syncMaxAttempts,
+			"delay_ms":   delay.Milliseconds(),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			continue
+		case <-ctx.Done():
+			// Shutdown ran out of time; stop retrying but keep the event
+			timer.Stop()
+			err = fmt.Errorf("%w (retries interrupted by shutdown)", err)
 		}
+		break
 	}
 
 	log.Error("Failed to sync user after all retries", map[string]interface{}{
-		"request_id": requestID,
-		"user_id":    user.ID.String(),
-		"username":   user.Username,
-		"action":     action,
-		"attempts":   maxRetries,
+		"request_id": event.RequestID,
+		"event_id":   event.ID,
+		"user_id":    event.Subject,
+		"type":       event.Type,
+		"attempts":   attempts,
 	})
-	// TODO: Send to dead letter queue or alert monitoring
+	s.deadLetter(event, attempts, err)
+	return err
+}
+
+// deadLetter stores an undelivered event in the dead-letter queue
+func (s *SyncService) deadLetter(event *cloudevents.Event, attempts int, cause error) {
+	// Independent of the job context, which may already be cancelled
+	ctx := contextutil.WithRequestID(context.Background(), event.RequestID)
+
+	topic := event.Type
+	if schema, ok := services.LookupEventSchema(event.Type); ok {
+		topic = schema.Topic
+	}
+
+	data, _ := json.Marshal(event)
+	now := time.Now()
+	if err := s.deadLetters.Add(ctx, &models.DeadLetterEvent{
+		ID:            uuid.MustParse(event.ID),
+		AggregateID:   uuid.MustParse(event.Subject),
+		Topic:         topic,
+		Payload:       data,
+		Source:        models.DeadLetterSourceSync,
+		Attempts:      attempts,
+		LastError:     cause.Error(),
+		CreatedAt:     now,
+		LastAttemptAt: now,
+	}); err != nil {
+		logger.GetLogger().Error("Failed to store event in dead-letter queue; the event is lost", map[string]interface{}{
+			"request_id": event.RequestID,
+			"event_id":   event.ID,
+			"user_id":    event.Subject,
+			"type":       event.Type,
+			"error":      err.Error(),
+		})
+	}
 }
 
 // getMethod returns sync method name for logging
@@ -202,5 +305,5 @@ func getMethod(useEvents bool) string {
 	if useEvents {
 		return "events"
 	}
-	return "http"
+	return "webhooks"
 }
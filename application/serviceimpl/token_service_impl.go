@@ -0,0 +1,482 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type TokenServiceImpl struct {
+	userRepo       repositories.UserRepository
+	orgRepo        repositories.OrganizationRepository
+	revocationRepo repositories.TokenRevocationRepository
+	suspensionRepo repositories.SuspensionRepository
+	impersonations repositories.ImpersonationRepository
+	rbacService    services.RBACService
+	patService     services.PersonalAccessTokenService
+	sessionService services.SessionService
+	auditService   services.AuditService
+	jwtConfig      config.JWTConfig
+}
+
+func NewTokenService(
+	userRepo repositories.UserRepository,
+	orgRepo repositories.OrganizationRepository,
+	revocationRepo repositories.TokenRevocationRepository,
+	suspensionRepo repositories.SuspensionRepository,
+	impersonations repositories.ImpersonationRepository,
+	rbacService services.RBACService,
+	patService services.PersonalAccessTokenService,
+	sessionService services.SessionService,
+	auditService services.AuditService,
+	jwtConfig config.JWTConfig,
+) services.TokenService {
+	return &TokenServiceImpl{
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		revocationRepo: revocationRepo,
+		suspensionRepo: suspensionRepo,
+		impersonations: impersonations,
+		rbacService:    rbacService,
+		patService:     patService,
+		sessionService: sessionService,
+		auditService:   auditService,
+		jwtConfig:      jwtConfig,
+	}
+}
+
+func (s *TokenServiceImpl) GenerateAccessToken(ctx context.Context, user *models.User) (string, error) {
+	membership, err := s.orgRepo.GetLastActiveMembership(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	// Every sign-in starts a new session
+	sessionID, err := s.openSession(ctx, user.ID, uuid.Nil)
+	if err != nil {
+		return "", err
+	}
+
+	return s.signAccessToken(ctx, user, membership, sessionID)
+}
+
+// errImpersonatedSwitch stops an impersonation token from being exchanged for a regular one,
+// which would carry neither the act claim nor the impersonation session
+var errImpersonatedSwitch = errors.New("organization switch is not allowed while impersonating a user")
+
+func (s *TokenServiceImpl) GenerateAccessTokenForMembership(ctx context.Context, user *models.User, membership *models.OrganizationMember) (string, error) {
+	if contextutil.GetImpersonatorID(ctx) != uuid.Nil {
+		return "", errImpersonatedSwitch
+	}
+
+	// Switching organization stays within the caller's session
+	sessionID, err := s.openSession(ctx, user.ID, contextutil.GetSessionID(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	return s.signAccessToken(ctx, user, membership, sessionID)
+}
+
+// signAccessToken issues an access token carrying the user's current authorization
+func (s *TokenServiceImpl) signAccessToken(ctx context.Context, user *models.User, membership *models.OrganizationMember, sessionID uuid.UUID) (string, error) {
+	claims := s.newClaims(user, membership, utils.TokenTypeAccess, s.jwtConfig.AccessTokenTTL)
+	claims.SessionID = sessionID.String()
+
+	roles, permissions, err := s.rbacService.ResolveAuthorization(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	claims.Roles = roles
+	claims.Permissions = permissions
+
+	return utils.GenerateToken(claims, s.jwtConfig.Secret)
+}
+
+func (s *TokenServiceImpl) GenerateImpersonationToken(ctx context.Context, actor, target *models.User, sessionID uuid.UUID, expiresAt time.Time) (string, error) {
+	membership, err := s.orgRepo.GetLastActiveMembership(ctx, target.ID)
+	if err != nil {
+		return "", err
+	}
+
+	claims := s.newClaims(target, membership, utils.TokenTypeAccess, time.Until(expiresAt))
+	claims.ID = sessionID.String()
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	claims.Act = &utils.ActorClaim{
+		Subject:  actor.ID.String(),
+		Username: actor.Username,
+		Email:    actor.Email,
+	}
+
+	// The administrator sees exactly what the user would see
+	claims.Roles, claims.Permissions, err = s.rbacService.ResolveAuthorization(ctx, target)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.GenerateToken(claims, s.jwtConfig.Secret)
+}
+
+func (s *TokenServiceImpl) Authenticate(ctx context.Context, token string) (*utils.UserContext, error) {
+	if utils.IsPersonalAccessToken(token) {
+		return s.patService.Authenticate(ctx, token)
+	}
+
+	userCtx, err := utils.ValidateTokenStringToUUID(token, s.jwtConfig.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRevoked(ctx, userCtx.ID, userCtx.OrgID, userCtx.TokenID, userCtx.IssuedAt); err != nil {
+		return nil, err
+	}
+
+	if userCtx.IsImpersonated() {
+		if err := s.checkImpersonation(ctx, userCtx.ActorID, userCtx.TokenID, userCtx.IssuedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// Suspension must hold even when revoking the user's tokens failed, as for introspection
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, userCtx.ID, time.Now())
+	if err != nil {
+		return nil, s.revocationUnavailable(ctx, userCtx.ID, err)
+	}
+	if suspension != nil {
+		return nil, utils.ErrRevokedToken
+	}
+
+	if userCtx.SessionID != uuid.Nil {
+		revoked, err := s.sessionService.IsRevoked(ctx, userCtx.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, utils.ErrRevokedToken
+		}
+		s.sessionService.Touch(ctx, userCtx.SessionID)
+	}
+
+	// Tokens issued before RBAC carry no permissions claim; resolve it from the database
+	if userCtx.Permissions == nil {
+		user, err := s.userRepo.GetByID(ctx, userCtx.ID)
+		if err != nil {
+			return nil, utils.ErrInvalidToken
+		}
+		userCtx.Roles, userCtx.Permissions, err = s.rbacService.ResolveAuthorization(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return userCtx, nil
+}
+
+func (s *TokenServiceImpl) Introspect(ctx context.Context, token, tokenTypeHint string) (*dto.IntrospectionResponse, error) {
+	if utils.IsPersonalAccessToken(token) {
+		return s.patService.Introspect(ctx, token)
+	}
+
+	inactive := &dto.IntrospectionResponse{Active: false}
+
+	// Only access tokens are issued, so the hint does not change the lookup
+	claims, err := utils.ParseToken(token, s.jwtConfig.Secret)
+	if err != nil || !claims.IsAccessToken() {
+		return inactive, nil
+	}
+
+	user, err := s.activeUserForClaims(ctx, claims)
+	if errors.Is(err, utils.ErrRevocationUnavailable) {
+		return nil, err
+	}
+	if err != nil {
+		return inactive, nil
+	}
+
+	if claims.Act != nil {
+		actorID, err := uuid.Parse(claims.Act.Subject)
+		if err != nil || claims.IssuedAt == nil {
+			return inactive, nil
+		}
+		if err := s.checkImpersonation(ctx, actorID, claims.ID, claims.IssueTime()); err != nil {
+			if errors.Is(err, utils.ErrRevocationUnavailable) {
+				return nil, err
+			}
+			return inactive, nil
+		}
+	}
+
+	// Report current authorization rather than what was baked into the token
+	roles, permissions, err := s.rbacService.ResolveAuthorization(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.IntrospectionResponse{
+		Active:      true,
+		Scope:       claims.Scope,
+		Username:    user.Username,
+		TokenType:   "access_token",
+		Sub:         user.ID.String(),
+		Jti:         claims.ID,
+		Email:       user.Email,
+		Role:        user.Role,
+		Roles:       roles,
+		Permissions: permissions,
+	}
+	if claims.OrgID != "" {
+		if orgID, err := uuid.Parse(claims.OrgID); err == nil {
+			if membership, err := s.orgRepo.GetMember(ctx, orgID, user.ID); err == nil && membership != nil {
+				response.OrgID = claims.OrgID
+				response.OrgRole = membership.Role
+			}
+		}
+	}
+	if claims.Act != nil {
+		response.Act = &dto.IntrospectionActor{
+			Sub:      claims.Act.Subject,
+			Username: claims.Act.Username,
+		}
+	}
+	if claims.ExpiresAt != nil {
+		response.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		response.Iat = claims.IssuedAt.Unix()
+	}
+
+	return response, nil
+}
+
+func (s *TokenServiceImpl) Revoke(ctx context.Context, token, tokenTypeHint string) error {
+	if utils.IsPersonalAccessToken(token) {
+		return s.patService.RevokePlaintext(ctx, token)
+	}
+
+	claims, err := utils.ParseToken(token, s.jwtConfig.Secret)
+	if err != nil {
+		// Invalid or expired tokens need no revocation (RFC 7009 section 2.2)
+		return nil
+	}
+
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		// Legacy tokens without a jti can only be revoked user-wide
+		userID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			return nil
+		}
+		return s.RevokeAllForUser(ctx, userID)
+	}
+
+	if err := s.revocationRepo.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+
+	logger.GetLogger().Info("Token revoked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "token_revoke",
+		"user_id":    claims.UserID,
+		"jti":        claims.ID,
+	})
+
+	if userID, err := uuid.Parse(claims.UserID); err == nil {
+		s.auditService.Record(ctx, &models.AuditEvent{
+			EventType: models.AuditTokenRevoked,
+			SubjectID: &userID,
+			Metadata:  map[string]interface{}{"jti": claims.ID},
+		})
+	}
+
+	return nil
+}
+
+func (s *TokenServiceImpl) RevokeAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if err := s.revocationRepo.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return err
+	}
+	if err := s.sessionService.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.GetLogger().Info("All user tokens revoked", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "token_revoke_all",
+		"user_id":    userID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditTokensRevokedAll,
+		SubjectID: &userID,
+	})
+
+	return nil
+}
+
+// newClaims builds the common claims of a token of the given type
+func (s *TokenServiceImpl) newClaims(user *models.User, membership *models.OrganizationMember, tokenType string, ttl time.Duration) *utils.JWTClaims {
+	now := time.Now()
+	claims := &utils.JWTClaims{
+		UserID:     user.ID.String(),
+		Username:   user.Username,
+		Email:      user.Email,
+		Role:       user.Role,
+		TokenType:  tokenType,
+		IssuedAtMs: now.UnixMilli(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if membership != nil {
+		claims.OrgID = membership.OrganizationID.String()
+		claims.OrgRole = membership.Role
+	}
+	return claims
+}
+
+// activeUserForClaims loads the token's user and checks revocation and account state
+func (s *TokenServiceImpl) activeUserForClaims(ctx context.Context, claims *utils.JWTClaims) (*models.User, error) {
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, utils.ErrInvalidToken
+	}
+
+	orgID := uuid.Nil
+	if claims.OrgID != "" {
+		if orgID, err = uuid.Parse(claims.OrgID); err != nil {
+			return nil, utils.ErrInvalidToken
+		}
+	}
+
+	if err := s.checkRevoked(ctx, userID, orgID, claims.ID, claims.IssueTime()); err != nil {
+		return nil, err
+	}
+	if claims.SessionID != "" {
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return nil, utils.ErrInvalidToken
+		}
+		revoked, err := s.sessionService.IsRevoked(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, utils.ErrRevokedToken
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.IsActive {
+		return nil, errors.New("account is disabled")
+	}
+
+	// Covers introspection even if the revocation store missed the suspension
+	suspension, err := s.suspensionRepo.GetActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if suspension != nil {
+		return nil, errors.New("account is suspended")
+	}
+
+	return user, nil
+}
+
+// openSession starts a session when sessionID is uuid.Nil, otherwise checks that the session
+// is still open and extends it to the lifetime of a new access token
+func (s *TokenServiceImpl) openSession(ctx context.Context, userID, sessionID uuid.UUID) (uuid.UUID, error) {
+	expiresAt := time.Now().Add(s.jwtConfig.AccessTokenTTL)
+
+	if sessionID == uuid.Nil {
+		session, err := s.sessionService.Start(ctx, userID, expiresAt)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return session.ID, nil
+	}
+
+	if err := s.sessionService.Resume(ctx, userID, sessionID, expiresAt); err != nil {
+		return uuid.Nil, err
+	}
+	return sessionID, nil
+}
+
+// checkImpersonation verifies that the impersonation session behind a token is still open
+// and that the administrator's own sessions have not been revoked since it started.
+// The database is the source of truth for impersonation.
+func (s *TokenServiceImpl) checkImpersonation(ctx context.Context, actorID uuid.UUID, jti string, issuedAt time.Time) error {
+	sessionID, err := uuid.Parse(jti)
+	if err != nil {
+		return utils.ErrInvalidToken
+	}
+
+	session, err := s.impersonations.GetByID(ctx, sessionID)
+	if err != nil || session.ActorID != actorID {
+		return utils.ErrInvalidToken
+	}
+	if !session.IsActive(time.Now()) {
+		return utils.ErrRevokedToken
+	}
+
+	return s.checkRevoked(ctx, actorID, uuid.Nil, "", issuedAt)
+}
+
+// checkRevoked checks the token, user and membership revocation lists. It fails closed: when Redis cannot
+// be read it returns ErrRevocationUnavailable, since a revoked token must never be accepted.
+func (s *TokenServiceImpl) checkRevoked(ctx context.Context, userID, orgID uuid.UUID, jti string, issuedAt time.Time) error {
+	revoked, err := s.revocationRepo.IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return s.revocationUnavailable(ctx, userID, err)
+	}
+	if revoked {
+		return utils.ErrRevokedToken
+	}
+
+	before, err := s.revocationRepo.GetUserTokensRevokedBefore(ctx, userID)
+	if err != nil {
+		return s.revocationUnavailable(ctx, userID, err)
+	}
+
+	// Cutoffs and iat_ms have millisecond precision; a token issued at the cutoff is revoked
+	if before != nil && !issuedAt.After(*before) {
+		return utils.ErrRevokedToken
+	}
+
+	// Membership changes only revoke tokens scoped to that organization
+	if orgID == uuid.Nil {
+		return nil
+	}
+	before, err = s.revocationRepo.GetMembershipTokensRevokedBefore(ctx, userID, orgID)
+	if err != nil {
+		return s.revocationUnavailable(ctx, userID, err)
+	}
+	if before != nil && !issuedAt.After(*before) {
+		return utils.ErrRevokedToken
+	}
+	return nil
+}
+
+func (s *TokenServiceImpl) revocationUnavailable(ctx context.Context, userID uuid.UUID, err error) error {
+	logger.GetLogger().Error("Token revocation lookup failed", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"user_id":    userID.String(),
+		"error":      err.Error(),
+	})
+	return utils.ErrRevocationUnavailable
+}
@@ -2,32 +2,110 @@ package serviceimpl
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
 	"gofiber-template/pkg/contextutil"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/password"
+	"gofiber-template/pkg/utils"
+	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// errInvalidCredentials is the only answer before the password is verified, so the response does
+// not reveal whether an account exists or how it is configured
+var errInvalidCredentials = errors.New("invalid email or password")
+
 type UserServiceImpl struct {
-	userRepo    repositories.UserRepository
-	jwtSecret   string
-	syncService *SyncService
+	userRepo          repositories.UserRepository
+	resetRepo         repositories.PasswordResetRepository
+	patRepo           repositories.PersonalAccessTokenRepository
+	tokenService      services.TokenService
+	suspensionService services.SuspensionService
+	lockoutService    services.LockoutService
+	deviceService     services.DeviceService
+	riskService       services.RiskService
+	auditService      services.AuditService
+	syncService       *SyncService
+	emailSender       services.EmailSender
+	passwords         *password.Validator
+	hasher            password.Hasher
+	// dummyHash is verified against for accounts without a password, so their logins take as long
+	dummyHash string
+	config    *config.Config
 }
 
-func NewUserService(userRepo repositories.UserRepository, jwtSecret string, syncService *SyncService) services.UserService {
-	return &UserServiceImpl{
-		userRepo:    userRepo,
-		jwtSecret:   jwtSecret,
-		syncService: syncService,
+func NewUserService(
+	userRepo repositories.UserRepository,
+	resetRepo repositories.PasswordResetRepository,
+	patRepo repositories.PersonalAccessTokenRepository,
+	tokenService services.TokenService,
+	suspensionService services.SuspensionService,
+	lockoutService services.LockoutService,
+	deviceService services.DeviceService,
+	riskService services.RiskService,
+	auditService services.AuditService,
+	syncService *SyncService,
+	emailSender services.EmailSender,
+	cfg *config.Config,
+) services.UserService {
+	breached, err := password.NewBreachedList(cfg.Password.BreachedDir)
+	if err != nil {
+		logger.GetLogger().Warn("Breached password list not loaded", map[string]interface{}{
+			"action": "password_policy",
+			"error":  err.Error(),
+		})
+	}
+
+	svc := &UserServiceImpl{
+		userRepo:          userRepo,
+		resetRepo:         resetRepo,
+		patRepo:           patRepo,
+		tokenService:      tokenService,
+		suspensionService: suspensionService,
+		lockoutService:    lockoutService,
+		deviceService:     deviceService,
+		riskService:       riskService,
+		auditService:      auditService,
+		syncService:       syncService,
+		emailSender:       emailSender,
+		passwords: password.NewValidator(password.Policy{
+			MinLength:     cfg.Password.MinLength,
+			MaxLength:     cfg.Password.MaxLength,
+			RequireUpper:  cfg.Password.RequireUpper,
+			RequireLower:  cfg.Password.RequireLower,
+			RequireDigit:  cfg.Password.RequireDigit,
+			RequireSymbol: cfg.Password.RequireSymbol,
+			MinScore:      cfg.Password.MinScore,
+			BannedWords:   cfg.Password.BannedWords,
+		}, breached),
+		hasher: password.NewHasher(password.Argon2Params{
+			Memory:      cfg.Password.Argon2Memory,
+			Iterations:  cfg.Password.Argon2Iterations,
+			Parallelism: cfg.Password.Argon2Parallelism,
+			SaltLength:  16,
+			KeyLength:   32,
+		}),
+		config: cfg,
+	}
+
+	dummyHash, err := svc.hasher.Hash("dummy-password-for-unknown-accounts")
+	if err != nil {
+		logger.GetLogger().Warn("Failed to prepare dummy password hash", map[string]interface{}{
+			"error": err.Error(),
+		})
 	}
+	svc.dummyHash = dummyHash
+	return svc
 }
 
 func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserRequest) (*models.User, error) {
@@ -62,7 +140,16 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 		return nil, errors.New("username already exists")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err := s.passwords.Validate(req.Password, req.Email, req.Username, req.DisplayName); err != nil {
+		log.Warn("Registration failed: password rejected by policy", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "register",
+			"email":      req.Email,
+		})
+		return nil, err
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		log.Error("Password hashing failed", map[string]interface{}{
 			"request_id": requestID,
@@ -72,12 +159,11 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 		return nil, err
 	}
 
-	passwordStr := string(hashedPassword)
 	user := &models.User{
 		ID:          uuid.New(),
 		Email:       req.Email,
 		Username:    req.Username,
-		Password:    &passwordStr,
+		Password:    &hashedPassword,
 		DisplayName: req.DisplayName,
 		Role:        "user",
 		IsActive:    true,
@@ -85,7 +171,8 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 		UpdatedAt:   time.Now(),
 	}
 
-	err = s.userRepo.Create(ctx, user)
+	// The created event is stored with the user and delivered by the outbox relay
+	err = s.userRepo.Create(ctx, user, s.syncService.UserEvent(ctx, user, "created"))
 	if err != nil {
 		log.Error("User creation failed", map[string]interface{}{
 			"request_id": requestID,
@@ -104,9 +191,6 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 		"duration_ms": duration,
 	})
 
-	// Sync to backend (async with context)
-	go s.syncService.SyncUserWithRetry(ctx, user, "created")
-
 	return user, nil
 }
 
@@ -121,24 +205,28 @@ func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (str
 		"email":      req.Email,
 	})
 
-	user, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err != nil {
-		log.Warn("Login failed: user not found", map[string]interface{}{
+	// Throttled before the lookup so the answer is the same for unknown accounts
+	if err := s.lockoutService.CheckLogin(ctx, req.Email); err != nil {
+		log.Warn("Login failed: too many failed attempts", map[string]interface{}{
 			"request_id": requestID,
 			"action":     "login",
 			"email":      req.Email,
 		})
-		return "", nil, errors.New("invalid email or password")
+		s.recordLoginFailure(ctx, nil, req.Email, "throttled")
+		return "", nil, err
 	}
 
-	if !user.IsActive {
-		log.Warn("Login failed: account disabled", map[string]interface{}{
+	user, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		log.Warn("Login failed: user not found", map[string]interface{}{
 			"request_id": requestID,
 			"action":     "login",
-			"user_id":    user.ID.String(),
 			"email":      req.Email,
 		})
-		return "", nil, errors.New("account is disabled")
+		s.verifyDummyPassword(req.Password)
+		s.recordLoginFailure(ctx, nil, req.Email, "unknown_email")
+		s.lockoutService.RecordFailure(ctx, req.Email, nil)
+		return "", nil, errInvalidCredentials
 	}
 
 	if user.Password == nil {
@@ -148,21 +236,71 @@ func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (str
 			"user_id":    user.ID.String(),
 			"email":      req.Email,
 		})
-		return "", nil, errors.New("invalid email or password")
+		s.verifyDummyPassword(req.Password)
+		s.recordLoginFailure(ctx, user, req.Email, "no_password")
+		s.lockoutService.RecordFailure(ctx, req.Email, user)
+		return "", nil, errInvalidCredentials
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(req.Password))
+	match, needsRehash, err := s.hasher.Verify(req.Password, *user.Password)
 	if err != nil {
+		log.Error("Password verification failed", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "login",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+	if !match {
 		log.Warn("Login failed: invalid password", map[string]interface{}{
 			"request_id": requestID,
 			"action":     "login",
 			"user_id":    user.ID.String(),
 			"email":      req.Email,
 		})
-		return "", nil, errors.New("invalid email or password")
+		s.recordLoginFailure(ctx, user, req.Email, "invalid_password")
+		s.lockoutService.RecordFailure(ctx, req.Email, user)
+		return "", nil, errInvalidCredentials
 	}
 
-	token, err := s.GenerateJWT(user)
+	// Checked after the password so only the account owner learns that it is disabled
+	if !user.IsActive {
+		log.Warn("Login failed: account disabled", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "login",
+			"user_id":    user.ID.String(),
+			"email":      req.Email,
+		})
+		s.recordLoginFailure(ctx, user, req.Email, "account_disabled")
+		return "", nil, errors.New("account is disabled")
+	}
+
+	if needsRehash {
+		s.upgradePasswordHash(ctx, user, req.Password)
+	}
+
+	// Checked after the password so suspension details are only shown to the account owner
+	if err := s.suspensionService.CheckSignIn(ctx, user.ID); err != nil {
+		log.Warn("Login failed: account suspended", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "login",
+			"user_id":    user.ID.String(),
+			"email":      req.Email,
+		})
+		s.recordLoginFailure(ctx, user, req.Email, "account_suspended")
+		return "", nil, err
+	}
+
+	// Assessed before the failure counters are cleared, which are one of the signals
+	if err := s.riskService.CheckLogin(ctx, user, "password"); err != nil {
+		if errors.Is(err, services.ErrLoginDenied) {
+			s.recordLoginFailure(ctx, user, req.Email, "risk_denied")
+		}
+		return "", nil, err
+	}
+	s.lockoutService.RecordSuccess(ctx, req.Email)
+
+	token, err := s.tokenService.GenerateAccessToken(ctx, user)
 	if err != nil {
 		log.Error("JWT generation failed", map[string]interface{}{
 			"request_id": requestID,
@@ -172,6 +310,7 @@ func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (str
 		})
 		return "", nil, err
 	}
+	s.recordLastLogin(ctx, user)
 
 	duration := time.Since(startTime).Milliseconds()
 	log.Info("User logged in successfully", map[string]interface{}{
@@ -182,9 +321,81 @@ func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (str
 		"duration_ms": duration,
 	})
 
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginSucceeded,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+		Metadata:  map[string]interface{}{"method": "password"},
+	})
+	s.deviceService.RecordLogin(ctx, user, "password")
+	s.syncService.SyncUserEventAsync(ctx, user, services.UserEventData{Action: "logged_in", Method: "password"})
+
 	return token, user, nil
 }
 
+// upgradePasswordHash re-hashes a password verified against a legacy algorithm or outdated
+// parameters. Failures are logged only; the old hash keeps working.
+func (s *UserServiceImpl) upgradePasswordHash(ctx context.Context, user *models.User, plain string) {
+	log := logger.GetLogger()
+
+	hashedPassword, err := s.hasher.Hash(plain)
+	if err == nil {
+		err = s.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{"password": hashedPassword})
+	}
+	if err != nil {
+		log.Warn("Failed to upgrade password hash", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"action":     "password_rehash",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	user.Password = &hashedPassword
+	log.Info("Password hash upgraded", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "password_rehash",
+		"user_id":    user.ID.String(),
+	})
+}
+
+// verifyDummyPassword spends the time of a real verification when there is no hash to check,
+// so response times do not reveal whether an account exists
+func (s *UserServiceImpl) verifyDummyPassword(plain string) {
+	if s.dummyHash != "" {
+		_, _, _ = s.hasher.Verify(plain, s.dummyHash)
+	}
+}
+
+// recordLastLogin stores the sign-in time; a failed write does not fail the login
+func (s *UserServiceImpl) recordLastLogin(ctx context.Context, user *models.User) {
+	now := time.Now()
+	if err := s.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{"last_login_at": now}); err != nil {
+		logger.GetLogger().Warn("Failed to record last login", map[string]interface{}{
+			"request_id": contextutil.GetRequestID(ctx),
+			"action":     "login",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+		return
+	}
+	user.LastLoginAt = &now
+}
+
+// recordLoginFailure audits a failed password login; user is nil when the email is unknown
+func (s *UserServiceImpl) recordLoginFailure(ctx context.Context, user *models.User, email, reason string) {
+	event := &models.AuditEvent{
+		EventType: models.AuditLoginFailed,
+		Outcome:   models.AuditOutcomeFailure,
+		Metadata:  map[string]interface{}{"method": "password", "email": email, "reason": reason},
+	}
+	if user != nil {
+		event.SubjectID = &user.ID
+	}
+	s.auditService.Record(ctx, event)
+}
+
 func (s *UserServiceImpl) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -208,98 +419,477 @@ func (s *UserServiceImpl) UpdateProfile(ctx context.Context, userID uuid.UUID, r
 
 	user.UpdatedAt = time.Now()
 
-	err = s.userRepo.Update(ctx, userID, user)
+	err = s.userRepo.Update(ctx, userID, user, s.syncService.UserEvent(ctx, user, "updated"))
 	if err != nil {
 		return nil, err
 	}
 
-	// Sync to backend (async with context)
-	go s.syncService.SyncUserWithRetry(ctx, user, "updated")
-
 	return user, nil
 }
 
 func (s *UserServiceImpl) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	// Get user first for sync
+	// Get user first for the deleted event
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.userRepo.Delete(ctx, userID)
+	return s.userRepo.Delete(ctx, userID, s.syncService.UserEvent(ctx, user, "deleted"))
+}
+
+func (s *UserServiceImpl) ListUsers(ctx context.Context, query *dto.UserListQuery) ([]*models.User, *dto.PaginationMeta, error) {
+	filter, err := userFilterFromQuery(query)
+	if err != nil {
+		return nil, nil, &services.InvalidUserQueryError{Reason: err.Error()}
+	}
+
+	// Fetch one extra row to know whether another page follows
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	users, total, err := s.userRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := &dto.PaginationMeta{
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  limit,
+	}
+	if len(users) > limit {
+		users = users[:limit]
+		meta.NextCursor = encodeUserCursor(filter, users[len(users)-1])
+	}
+
+	return users, meta, nil
+}
+
+// RequestPasswordReset emails a single-use reset link. Unknown or disabled accounts are
+// ignored without an error so the endpoint does not reveal which emails are registered.
+func (s *UserServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	requestID := contextutil.GetRequestID(ctx)
+	log := logger.GetLogger()
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		log.Info("Password reset requested for unknown or disabled account", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "password_reset_request",
+		})
+		return nil
+	}
+
+	now := time.Now()
+
+	// Only the most recent link stays valid
+	if err := s.resetRepo.InvalidateForUser(ctx, user.ID, now); err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(s.config.Password.ResetTokenTTL),
+		CreatedAt: now,
+	}
+	if err := s.resetRepo.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", s.config.App.FrontendURL, token)
+	message := &services.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"A password reset was requested for your account.\n\nSet a new password: %s\n\nThis link expires in %s. If you did not request it, you can ignore this email.",
+			resetURL, s.config.Password.ResetTokenTTL,
+		),
+	}
+	if err := s.emailSender.Send(ctx, message); err != nil {
+		log.Error("Failed to send password reset email", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "password_reset_request",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+		return err
+	}
+
+	log.Info("Password reset email sent", map[string]interface{}{
+		"request_id": requestID,
+		"action":     "password_reset_request",
+		"user_id":    user.ID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditPasswordResetRequested,
+		SubjectID: &user.ID,
+	})
+
+	return nil
+}
+
+func (s *UserServiceImpl) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	requestID := contextutil.GetRequestID(ctx)
+	log := logger.GetLogger()
+
+	resetToken, err := s.resetRepo.GetByTokenHash(ctx, utils.HashToken(req.Token))
+	if err != nil || !resetToken.IsUsable(time.Now()) {
+		return errors.New("reset token is invalid or has expired")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, resetToken.UserID)
+	if err != nil || !user.IsActive {
+		return errors.New("reset token is invalid or has expired")
+	}
+
+	// Checked before the token is consumed so the user can retry with another password
+	if err := s.passwords.Validate(req.NewPassword, user.Email, user.Username, user.DisplayName); err != nil {
+		return err
+	}
+
+	consumed, err := s.resetRepo.MarkUsed(ctx, resetToken.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return errors.New("reset token is invalid or has expired")
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.NewPassword)
 	if err != nil {
 		return err
 	}
 
-	// Sync deletion to backend (async with context)
-	go s.syncService.SyncUserWithRetry(ctx, user, "deleted")
+	user.Password = &hashedPassword
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user.ID, user, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action: "password_changed",
+		Reason: services.PasswordReset,
+	})); err != nil {
+		return err
+	}
+
+	// Sessions opened with the old password must not survive the reset
+	if err := s.tokenService.RevokeAllForUser(ctx, user.ID); err != nil {
+		log.Warn("Failed to revoke tokens after password reset", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "password_reset",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+	// Personal access tokens may have been created by whoever knew the old password
+	if err := s.patRepo.RevokeAllForUser(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+
+	log.Info("Password reset completed", map[string]interface{}{
+		"request_id": requestID,
+		"action":     "password_reset",
+		"user_id":    user.ID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditPasswordReset,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+	})
 
 	return nil
 }
 
-func (s *UserServiceImpl) ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int64, error) {
-	users, err := s.userRepo.List(ctx, offset, limit)
+func (s *UserServiceImpl) ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) error {
+	requestID := contextutil.GetRequestID(ctx)
+	log := logger.GetLogger()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, 0, err
+		return errors.New("user not found")
+	}
+	if user.Password == nil {
+		return errors.New("account has no password, use password reset to set one")
+	}
+	if match, _, _ := s.hasher.Verify(req.CurrentPassword, *user.Password); !match {
+		return errors.New("current password is incorrect")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return errors.New("new password must be different from the current password")
 	}
 
-	count, err := s.userRepo.Count(ctx)
+	if err := s.passwords.Validate(req.NewPassword, user.Email, user.Username, user.DisplayName); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.NewPassword)
 	if err != nil {
-		return nil, 0, err
+		return err
+	}
+
+	user.Password = &hashedPassword
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user.ID, user, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action: "password_changed",
+		Reason: services.PasswordChangedByUser,
+	})); err != nil {
+		return err
 	}
 
-	return users, count, nil
+	// Other sessions may belong to whoever knew the old password
+	if err := s.tokenService.RevokeAllForUser(ctx, user.ID); err != nil {
+		log.Warn("Failed to revoke tokens after password change", map[string]interface{}{
+			"request_id": requestID,
+			"action":     "password_change",
+			"user_id":    user.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+	if err := s.patRepo.RevokeAllForUser(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+
+	log.Info("Password changed", map[string]interface{}{
+		"request_id": requestID,
+		"action":     "password_change",
+		"user_id":    user.ID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditPasswordChanged,
+		SubjectID: &user.ID,
+	})
+
+	return nil
 }
 
-func (s *UserServiceImpl) GenerateJWT(user *models.User) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":  user.ID.String(),
-		"username": user.Username,
-		"email":    user.Email,
-		"role":     user.Role,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(),
-		"iat":      time.Now().Unix(),
+func (s *UserServiceImpl) VerifyLogin(ctx context.Context, req *dto.VerifyLoginRequest) (string, *models.User, error) {
+	user, method, err := s.riskService.VerifyChallenge(ctx, req.ChallengeID, req.Code)
+	if err != nil {
+		return "", nil, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.jwtSecret))
+	// The account may have been disabled or suspended while the code was in flight
+	if !user.IsActive {
+		return "", nil, errors.New("account is disabled")
+	}
+	if err := s.suspensionService.CheckSignIn(ctx, user.ID); err != nil {
+		return "", nil, err
+	}
+	s.lockoutService.RecordSuccess(ctx, user.Email)
+
+	token, err := s.tokenService.GenerateAccessToken(ctx, user)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
+	s.recordLastLogin(ctx, user)
+
+	logger.GetLogger().Info("User logged in after verification", map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     "login_verify",
+		"user_id":    user.ID.String(),
+		"method":     method,
+	})
 
-	return tokenString, nil
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginSucceeded,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+		Metadata:  map[string]interface{}{"method": method, "step_up": "email_otp"},
+	})
+	s.deviceService.RecordLogin(ctx, user, method)
+	s.syncService.SyncUserEventAsync(ctx, user, services.UserEventData{Action: "logged_in", Method: method})
+
+	return token, user, nil
 }
 
-func (s *UserServiceImpl) ValidateJWT(tokenString string) (*models.User, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(s.jwtSecret), nil
+func (s *UserServiceImpl) ReportUnrecognizedLogin(ctx context.Context, token string) error {
+	requestID := contextutil.GetRequestID(ctx)
+	log := logger.GetLogger()
+
+	alert, err := s.deviceService.ConsumeAlert(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, alert.UserID)
+	if err != nil {
+		return errors.New("link is invalid or has expired")
+	}
+
+	// Clearing the hash blocks password login until the user sets a new one
+	if err := s.userRepo.UpdateFields(ctx, user.ID, map[string]interface{}{
+		"password":   nil,
+		"updated_at": time.Now(),
+	}, s.syncService.UserEventWith(ctx, user, services.UserEventData{
+		Action: "password_changed",
+		Reason: services.PasswordCleared,
+	})); err != nil {
+		return err
+	}
+
+	if err := s.tokenService.RevokeAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.patRepo.RevokeAllForUser(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+
+	log.Warn("Sign-in reported as unrecognized", map[string]interface{}{
+		"request_id": requestID,
+		"action":     "login_denied",
+		"user_id":    user.ID.String(),
+		"alert_id":   alert.ID.String(),
+	})
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditLoginDenied,
+		ActorID:   &user.ID,
+		SubjectID: &user.ID,
+		Metadata: map[string]interface{}{
+			"alert_id":   alert.ID.String(),
+			"device":     alert.DeviceName,
+			"ip_address": alert.IPAddress,
+		},
 	})
 
+	return s.RequestPasswordReset(ctx, user.Email)
+}
+
+func (s *UserServiceImpl) GenerateJWT(ctx context.Context, user *models.User) (string, error) {
+	return s.tokenService.GenerateAccessToken(ctx, user)
+}
+
+func (s *UserServiceImpl) ValidateJWT(tokenString string) (*models.User, error) {
+	userCtx, err := s.tokenService.Authenticate(context.Background(), tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userIDStr, ok := claims["user_id"].(string)
+	user, err := s.userRepo.GetByID(context.Background(), userCtx.ID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	return user, nil
+}
+
+// userCursor is the opaque keyset position returned as nextCursor
+type userCursor struct {
+	SortBy   string    `json:"s"`
+	SortDesc bool      `json:"d"`
+	Value    string    `json:"v"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// userSortKeys maps API sort names to repository sort keys
+var userSortKeys = map[string]string{
+	"createdAt":   repositories.UserSortCreatedAt,
+	"updatedAt":   repositories.UserSortUpdatedAt,
+	"lastLoginAt": repositories.UserSortLastLoginAt,
+	"email":       repositories.UserSortEmail,
+	"username":    repositories.UserSortUsername,
+}
+
+func userFilterFromQuery(query *dto.UserListQuery) (*repositories.UserFilter, error) {
+	filter := &repositories.UserFilter{
+		Search:        strings.TrimSpace(query.Q),
+		Role:          query.Role,
+		IsActive:      query.Active,
+		Provider:      query.Provider,
+		EmailVerified: query.EmailVerified,
+		SortBy:        repositories.UserSortCreatedAt,
+		Offset:        query.Offset,
+		Limit:         query.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = dto.DefaultUserPageSize
+	}
+	if filter.Limit > dto.MaxUserPageSize {
+		filter.Limit = dto.MaxUserPageSize
+	}
+
+	if query.Sort != "" {
+		sortKey := strings.TrimPrefix(query.Sort, "-")
+		key, ok := userSortKeys[sortKey]
 		if !ok {
-			return nil, errors.New("invalid token claims")
+			return nil, fmt.Errorf("invalid sort key: %s", query.Sort)
 		}
+		filter.SortBy = key
+		filter.SortDesc = strings.HasPrefix(query.Sort, "-")
+	}
 
-		userID, err := uuid.Parse(userIDStr)
+	ranges := []struct {
+		value  string
+		target **time.Time
+	}{
+		{query.CreatedFrom, &filter.CreatedFrom},
+		{query.CreatedTo, &filter.CreatedTo},
+		{query.LastLoginFrom, &filter.LastLoginFrom},
+		{query.LastLoginTo, &filter.LastLoginTo},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, r.value)
 		if err != nil {
-			return nil, errors.New("invalid user ID in token")
+			return nil, fmt.Errorf("invalid timestamp: %s", r.value)
 		}
+		*r.target = &t
+	}
 
-		user, err := s.userRepo.GetByID(context.Background(), userID)
+	if query.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(query.Cursor)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, errors.New("invalid cursor")
 		}
+		var cursor userCursor
+		if err := json.Unmarshal(raw, &cursor); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		// A cursor only makes sense for the ordering it was produced with
+		if cursor.SortBy != filter.SortBy || cursor.SortDesc != filter.SortDesc {
+			return nil, errors.New("cursor does not match the requested sort")
+		}
+		filter.AfterValue = cursor.Value
+		filter.AfterID = &cursor.ID
+		filter.Offset = 0
+	}
+
+	return filter, nil
+}
+
+func encodeUserCursor(filter *repositories.UserFilter, last *models.User) string {
+	cursor := userCursor{
+		SortBy:   filter.SortBy,
+		SortDesc: filter.SortDesc,
+		ID:       last.ID,
+	}
 
-		return user, nil
+	switch filter.SortBy {
+	case repositories.UserSortUpdatedAt:
+		cursor.Value = last.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case repositories.UserSortLastLoginAt:
+		// Matches the repository's COALESCE for users who never logged in
+		lastLogin := time.Unix(0, 0)
+		if last.LastLoginAt != nil {
+			lastLogin = *last.LastLoginAt
+		}
+		cursor.Value = lastLogin.UTC().Format(time.RFC3339Nano)
+	case repositories.UserSortEmail:
+		cursor.Value = last.Email
+	case repositories.UserSortUsername:
+		cursor.Value = last.Username
+	default:
+		cursor.Value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
 	}
 
-	return nil, errors.New("invalid token")
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
 }
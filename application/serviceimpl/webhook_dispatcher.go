@@ -0,0 +1,323 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/metrics"
+)
+
+// webhookLeaseMargin is added to the request timeout when claiming deliveries, so a
+// dispatcher that dies mid-batch leaves them to be picked up again shortly after.
+const webhookLeaseMargin = time.Minute
+
+// webhookResponseLimit is how much of the response body is kept for troubleshooting
+const webhookResponseLimit = 1000
+
+// WebhookDispatcher sends queued webhook deliveries. Every request carries an HMAC-SHA256
+// signature over the timestamp and body; failed deliveries are retried with exponential
+// backoff, and endpoints that keep failing are disabled.
+type WebhookDispatcher struct {
+	webhookRepo  repositories.WebhookRepository
+	auditService services.AuditService
+	httpClient   *http.Client
+	cfg          config.WebhookConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+func NewWebhookDispatcher(
+	webhookRepo repositories.WebhookRepository,
+	auditService services.AuditService,
+	cfg *config.Config,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookRepo:  webhookRepo,
+		auditService: auditService,
+		httpClient: &http.Client{
+			Timeout: cfg.Webhook.Timeout,
+			// A redirect would send the signed payload somewhere the endpoint was not registered for
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg: cfg.Webhook,
+	}
+}
+
+// Start runs the dispatch loop in the background until Stop is called
+func (d *WebhookDispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(ctx)
+}
+
+// Stop ends the dispatch loop and waits for the requests in flight to finish
+func (d *WebhookDispatcher) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cancel == nil {
+		return
+	}
+
+	d.cancel()
+	<-d.done
+	d.cancel = nil
+}
+
+func (d *WebhookDispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Keep draining while there is work; otherwise wait for the next poll
+		sent, err := d.DeliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.GetLogger().Error("Webhook dispatch failed", map[string]interface{}{
+				"action": "webhook_dispatch",
+				"error":  err.Error(),
+			})
+		}
+
+		if sent > 0 {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.cfg.PollInterval)
+		}
+	}
+}
+
+// DeliverDue sends one batch of due deliveries concurrently and returns how many were attempted
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, now, now.Add(d.cfg.Timeout+webhookLeaseMargin), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Let requests in flight finish and record their outcome when shutdown cancels ctx
+	sendCtx := context.WithoutCancel(ctx)
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.attempt(sendCtx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	log := logger.GetLogger()
+	endpoint := delivery.Endpoint
+
+	start := time.Now()
+	statusCode, response, sendErr := d.send(ctx, &endpoint, delivery)
+	duration := time.Since(start)
+	metrics.WebhookDeliveryDuration.Observe(duration.Seconds())
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastResponse = response
+	delivery.LastDurationMs = duration.Milliseconds()
+
+	if sendErr == nil {
+		metrics.WebhookDeliveryAttemptsTotal.WithLabelValues("success").Inc()
+		now := time.Now()
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			// The delivery stays leased and is sent again after the lease; receivers deduplicate by event ID
+			log.Error("Failed to record webhook delivery", map[string]interface{}{
+				"action":      "webhook_dispatch",
+				"delivery_id": delivery.ID.String(),
+				"error":       err.Error(),
+			})
+		}
+		if endpoint.ConsecutiveFailures > 0 {
+			if err := d.webhookRepo.RecordSuccess(ctx, endpoint.ID); err != nil {
+				log.Warn("Failed to reset webhook failure count", map[string]interface{}{
+					"action":     "webhook_dispatch",
+					"webhook_id": endpoint.ID.String(),
+					"error":      err.Error(),
+				})
+			}
+		}
+		return
+	}
+
+	metrics.WebhookDeliveryAttemptsTotal.WithLabelValues("failure").Inc()
+	delivery.LastError = truncate(sendErr.Error(), 500)
+
+	fields := map[string]interface{}{
+		"action":      "webhook_dispatch",
+		"webhook_id":  endpoint.ID.String(),
+		"delivery_id": delivery.ID.String(),
+		"event_id":    delivery.EventID,
+		"event_type":  delivery.EventType,
+		"attempt":     delivery.Attempts,
+		"status_code": statusCode,
+		"error":       sendErr.Error(),
+	}
+	if d.cfg.MaxAttempts > 0 && delivery.Attempts >= d.cfg.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		log.Warn("Webhook delivery failed permanently", fields)
+	} else {
+		delay := d.retryDelay(delivery.Attempts - 1)
+		delivery.NextAttemptAt = time.Now().Add(delay)
+		fields["delay_ms"] = delay.Milliseconds()
+		log.Warn("Webhook delivery failed", fields)
+	}
+	if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error("Failed to record webhook delivery", map[string]interface{}{
+			"action":      "webhook_dispatch",
+			"delivery_id": delivery.ID.String(),
+			"error":       err.Error(),
+		})
+	}
+
+	failures, err := d.webhookRepo.RecordFailure(ctx, endpoint.ID)
+	if err != nil {
+		log.Warn("Failed to record webhook failure", map[string]interface{}{
+			"action":     "webhook_dispatch",
+			"webhook_id": endpoint.ID.String(),
+			"error":      err.Error(),
+		})
+		return
+	}
+	// Only the attempt that crosses the threshold disables the endpoint
+	if d.cfg.DisableAfter > 0 && failures == d.cfg.DisableAfter {
+		d.disable(ctx, &endpoint, failures, sendErr)
+	}
+}
+
+// send POSTs the delivery payload and returns the status code and the beginning of the response body
+func (d *WebhookDispatcher) send(ctx context.Context, endpoint *models.WebhookEndpoint, delivery *models.WebhookDelivery) (int, string, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set("User-Agent", "auth-service-webhooks/1.0")
+	req.Header.Set("X-Webhook-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", SignWebhookPayload(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(body), fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+func (d *WebhookDispatcher) disable(ctx context.Context, endpoint *models.WebhookEndpoint, failures int, cause error) {
+	now := time.Now()
+	reason := truncate(fmt.Sprintf("disabled after %d consecutive failed deliveries; last error: %s", failures, cause.Error()), 500)
+
+	err := d.webhookRepo.UpdateEndpoint(ctx, endpoint.ID, map[string]interface{}{
+		"is_active":       false,
+		"disabled_at":     now,
+		"disabled_reason": reason,
+		"updated_at":      now,
+	})
+	if err != nil {
+		logger.GetLogger().Error("Failed to disable webhook endpoint", map[string]interface{}{
+			"action":     "webhook_dispatch",
+			"webhook_id": endpoint.ID.String(),
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	metrics.WebhookEndpointsDisabledTotal.Inc()
+	logger.GetLogger().Error("Webhook endpoint disabled", map[string]interface{}{
+		"action":     "webhook_disabled",
+		"alert":      true,
+		"webhook_id": endpoint.ID.String(),
+		"url":        endpoint.URL,
+		"failures":   failures,
+		"error":      cause.Error(),
+	})
+
+	d.auditService.Record(ctx, &models.AuditEvent{
+		EventType: models.AuditWebhookDisabled,
+		Metadata: map[string]interface{}{
+			"webhook_id": endpoint.ID.String(),
+			"url":        endpoint.URL,
+			"failures":   failures,
+			"reason":     reason,
+		},
+	})
+}
+
+// DeleteFinished removes succeeded and failed deliveries older than the retention period
+func (d *WebhookDispatcher) DeleteFinished(ctx context.Context) (int64, error) {
+	return d.webhookRepo.DeleteDeliveriesBefore(ctx, time.Now().Add(-d.cfg.Retention))
+}
+
+// retryDelay returns the backoff after the given number of earlier failed attempts
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.cfg.RetryBase
+	for i := 0; i < attempts && delay < d.cfg.RetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.RetryMax)
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value for a request body:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the endpoint secret.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
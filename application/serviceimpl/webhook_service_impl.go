@@ -0,0 +1,336 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/cloudevents"
+	"gofiber-template/pkg/contextutil"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// webhookSecretPrefix marks generated signing secrets so they can be picked up by secret scanners
+const webhookSecretPrefix = "whsec_"
+
+var (
+	errWebhookNotFound         = errors.New("webhook endpoint not found")
+	errWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookServiceImpl struct {
+	webhookRepo  repositories.WebhookRepository
+	auditService services.AuditService
+}
+
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	auditService services.AuditService,
+) services.WebhookService {
+	return &WebhookServiceImpl{
+		webhookRepo:  webhookRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *WebhookServiceImpl) CreateEndpoint(ctx context.Context, actorID uuid.UUID, req *dto.CreateWebhookRequest) (*models.WebhookEndpoint, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		token, err := utils.GenerateRandomToken(32)
+		if err != nil {
+			return nil, err
+		}
+		secret = webhookSecretPrefix + token
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		URL:         req.URL,
+		Description: strings.TrimSpace(req.Description),
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		IsActive:    true,
+		CreatedBy:   &actorID,
+	}
+	if err := s.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, models.AuditWebhookCreated, actorID, endpoint, map[string]interface{}{
+		"event_types": endpoint.EventTypeList(),
+	})
+	return endpoint, nil
+}
+
+func (s *WebhookServiceImpl) ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	return s.webhookRepo.ListEndpoints(ctx)
+}
+
+func (s *WebhookServiceImpl) GetEndpoint(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, errWebhookNotFound
+	}
+	return endpoint, nil
+}
+
+func (s *WebhookServiceImpl) UpdateEndpoint(ctx context.Context, actorID, id uuid.UUID, req *dto.UpdateWebhookRequest) (*models.WebhookEndpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	changed := []string{}
+
+	if req.URL != nil && *req.URL != endpoint.URL {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		fields["url"] = *req.URL
+		endpoint.URL = *req.URL
+		changed = append(changed, "url")
+	}
+	if req.Description != nil {
+		fields["description"] = strings.TrimSpace(*req.Description)
+		endpoint.Description = strings.TrimSpace(*req.Description)
+		changed = append(changed, "description")
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		fields["event_types"] = eventTypes
+		endpoint.EventTypes = eventTypes
+		changed = append(changed, "event_types")
+	}
+	if req.Secret != nil {
+		fields["secret"] = *req.Secret
+		endpoint.Secret = *req.Secret
+		changed = append(changed, "secret")
+	}
+	if req.Active != nil && *req.Active != endpoint.IsActive {
+		fields["is_active"] = *req.Active
+		endpoint.IsActive = *req.Active
+		if *req.Active {
+			// Start counting failures afresh; pending deliveries resume
+			fields["consecutive_failures"] = 0
+			fields["disabled_at"] = nil
+			fields["disabled_reason"] = ""
+			endpoint.ConsecutiveFailures = 0
+			endpoint.DisabledAt = nil
+			endpoint.DisabledReason = ""
+		}
+		changed = append(changed, "active")
+	}
+
+	if len(fields) == 0 {
+		return endpoint, nil
+	}
+
+	endpoint.UpdatedAt = time.Now()
+	fields["updated_at"] = endpoint.UpdatedAt
+	if err := s.webhookRepo.UpdateEndpoint(ctx, id, fields); err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, models.AuditWebhookUpdated, actorID, endpoint, map[string]interface{}{
+		"fields": changed,
+	})
+	return endpoint, nil
+}
+
+func (s *WebhookServiceImpl) DeleteEndpoint(ctx context.Context, actorID, id uuid.UUID) error {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Deliveries are removed with the endpoint
+	if err := s.webhookRepo.DeleteEndpoint(ctx, id); err != nil {
+		return errWebhookNotFound
+	}
+
+	s.logAction(ctx, models.AuditWebhookDeleted, actorID, endpoint, nil)
+	return nil
+}
+
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, endpointID uuid.UUID, query *dto.WebhookDeliveryQuery) ([]*models.WebhookDelivery, *dto.PaginationMeta, error) {
+	if _, err := s.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, nil, err
+	}
+
+	filter := &repositories.WebhookDeliveryFilter{
+		EndpointID: endpointID,
+		Status:     query.Status,
+		EventType:  query.EventType,
+		Offset:     query.Offset,
+		Limit:      query.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = dto.DefaultWebhookDeliveryPageSize
+	}
+	if filter.Limit > dto.MaxWebhookDeliveryPageSize {
+		filter.Limit = dto.MaxWebhookDeliveryPageSize
+	}
+
+	deliveries, total, err := s.webhookRepo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deliveries, &dto.PaginationMeta{
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+func (s *WebhookServiceImpl) GetDelivery(ctx context.Context, endpointID, id uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDelivery(ctx, id)
+	if err != nil || delivery.EndpointID != endpointID {
+		return nil, errWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (s *WebhookServiceImpl) Redeliver(ctx context.Context, actorID, endpointID, id uuid.UUID) (*models.WebhookDelivery, error) {
+	endpoint, err := s.GetEndpoint(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := s.GetDelivery(ctx, endpointID, id)
+	if err != nil {
+		return nil, err
+	}
+	if original.Status == models.WebhookDeliveryPending {
+		return nil, errors.New("delivery is still pending")
+	}
+
+	// The event keeps its ID so the receiver can recognise a duplicate
+	delivery := &models.WebhookDelivery{
+		EndpointID:   original.EndpointID,
+		EventID:      original.EventID,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		RedeliveryOf: &original.ID,
+	}
+	if err := s.webhookRepo.CreateRedelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, models.AuditWebhookRedelivered, actorID, endpoint, map[string]interface{}{
+		"delivery_id":     original.ID.String(),
+		"redelivery_id":   delivery.ID.String(),
+		"event_id":        original.EventID,
+		"event_type":      original.EventType,
+		"endpoint_active": endpoint.IsActive,
+	})
+	return delivery, nil
+}
+
+func (s *WebhookServiceImpl) Enqueue(ctx context.Context, event *cloudevents.Event) error {
+	endpoints, err := s.webhookRepo.ListActiveEndpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	deliveries := []*models.WebhookDelivery{}
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			EndpointID: endpoint.ID,
+			EventID:    event.ID,
+			EventType:  event.Type,
+			Payload:    payload,
+		})
+	}
+
+	return s.webhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+func (s *WebhookServiceImpl) logAction(ctx context.Context, eventType string, actorID uuid.UUID, endpoint *models.WebhookEndpoint, extra map[string]interface{}) {
+	metadata := map[string]interface{}{
+		"webhook_id": endpoint.ID.String(),
+		"url":        endpoint.URL,
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+
+	fields := map[string]interface{}{
+		"request_id": contextutil.GetRequestID(ctx),
+		"action":     eventType,
+		"actor_id":   actorID.String(),
+	}
+	for k, v := range metadata {
+		fields[k] = v
+	}
+	logger.GetLogger().Info("Webhook endpoint changed", fields)
+
+	s.auditService.Record(ctx, &models.AuditEvent{
+		EventType: eventType,
+		ActorID:   &actorID,
+		Metadata:  metadata,
+	})
+}
+
+// validateWebhookURL accepts absolute http(s) URLs
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return errors.New("webhook URL must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// normalizeEventTypes checks the subscribed types and joins them for storage. A type is either
+// a published event type, "*" for every type, or a prefix ending in "*" such as "user.*".
+func normalizeEventTypes(eventTypes []string) (string, error) {
+	seen := map[string]bool{}
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" || seen[eventType] {
+			continue
+		}
+		if !strings.HasSuffix(eventType, "*") {
+			if _, ok := services.LookupEventSchema(eventType); !ok {
+				return "", fmt.Errorf("unknown event type %q", eventType)
+			}
+		}
+		seen[eventType] = true
+		normalized = append(normalized, eventType)
+	}
+	if len(normalized) == 0 {
+		return "", errors.New("at least one event type is required")
+	}
+	return strings.Join(normalized, " "), nil
+}
@@ -30,6 +30,13 @@ func main() {
 		log.Fatal("Failed to initialize container:", err)
 	}
 
+	// Wire token revocation checks into the auth middleware
+	middleware.InitAuth(container.TokenService)
+
+	// Wire shared rate limiting into the auth endpoints
+	middleware.InitRateLimit(container.RateLimiter, container.GetConfig().RateLimit)
+	middleware.InitDeviceCookie(container.GetConfig().App.Env == "production")
+
 	// Setup graceful shutdown
 	setupGracefulShutdown(container)
 
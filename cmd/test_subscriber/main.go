@@ -41,7 +41,7 @@ func main() {
 	log.Printf("📊 Last Seq: %d", stream.State.LastSeq)
 
 	// Subscribe to all user events
-	sub, err := js.Subscribe("user.events.*", func(msg *nats.Msg) {
+	sub, err := js.Subscribe("user.events.>", func(msg *nats.Msg) {
 		log.Println("\n🔔 Received event:")
 		log.Printf("Subject: %s", msg.Subject)
 		log.Printf("Size: %d bytes", len(msg.Data))
@@ -64,7 +64,7 @@ func main() {
 		log.Fatal("Failed to subscribe:", err)
 	}
 
-	log.Println("👂 Listening for events on user.events.*")
+	log.Println("👂 Listening for events on user.events.>")
 	log.Println("Press Ctrl+C to exit")
 
 	// Wait for interrupt signal
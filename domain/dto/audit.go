@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log page sizes
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 200
+	DefaultActivityLimit = 20
+)
+
+// AuditEventQuery holds the filters of GET /admin/audit-events. Timestamps are RFC 3339.
+// type matches exactly, or as a prefix when it ends with "." (e.g. "auth.").
+// Results are newest first; pass nextCursor from the previous page as cursor.
+type AuditEventQuery struct {
+	Type      string `query:"type" validate:"omitempty,max=100"`
+	Outcome   string `query:"outcome" validate:"omitempty,oneof=success failure"`
+	ActorID   string `query:"actorId" validate:"omitempty,uuid"`
+	SubjectID string `query:"subjectId" validate:"omitempty,uuid"`
+	From      string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Cursor    string `query:"cursor" validate:"omitempty,max=512"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+type SecurityActivityQuery struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type AuditEventResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	Type           string                 `json:"type"`
+	Outcome        string                 `json:"outcome"`
+	ActorID        *uuid.UUID             `json:"actorId"`
+	ImpersonatorID *uuid.UUID             `json:"impersonatorId,omitempty"`
+	SubjectID      *uuid.UUID             `json:"subjectId"`
+	IPAddress      string                 `json:"ipAddress,omitempty"`
+	UserAgent      string                 `json:"userAgent,omitempty"`
+	RequestID      string                 `json:"requestId,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+}
+
+type AuditEventListResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+}
+
+// SecurityActivityResponse is the user-facing view of an audit event.
+// It omits internal details such as administrator IDs and metadata.
+type SecurityActivityResponse struct {
+	Type      string    `json:"type"`
+	Outcome   string    `json:"outcome"`
+	ByAdmin   bool      `json:"byAdmin"` // Performed by an administrator rather than the user
+	IPAddress string    `json:"ipAddress,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -10,10 +10,23 @@ type LoginResponse struct {
 	User  UserResponse `json:"user"`
 }
 
+// VerifyLoginRequest confirms a risky sign-in with the code sent by email
+type VerifyLoginRequest struct {
+	ChallengeID string `json:"challengeId" validate:"required"`
+	Code        string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// LoginChallengeResponse is returned instead of tokens when a sign-in needs a one-time code
+type LoginChallengeResponse struct {
+	ChallengeID string `json:"challengeId"`
+	Method      string `json:"method"` // "email_otp"
+	ExpiresIn   int64  `json:"expiresIn"`
+}
+
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email,max=255"`
 	Username  string `json:"username" validate:"required,min=3,max=20,alphanum"`
-	Password  string `json:"password" validate:"required,min=8,max=72"`
+	Password  string `json:"password" validate:"required,max=256"`
 	FirstName string `json:"firstName" validate:"required,min=1,max=50"`
 	LastName  string `json:"lastName" validate:"required,min=1,max=50"`
 }
@@ -38,10 +51,15 @@ type ForgotPasswordRequest struct {
 
 type ResetPasswordRequest struct {
 	Token           string `json:"token" validate:"required"`
-	NewPassword     string `json:"newPassword" validate:"required,min=8,max=72"`
+	NewPassword     string `json:"newPassword" validate:"required,max=256"`
 	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=NewPassword"`
 }
 
+// ReportLoginRequest redeems the "this wasn't me" link of a new sign-in email
+type ReportLoginRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
 type LogoutResponse struct {
 	Message string `json:"message"`
 }
\ No newline at end of file
@@ -18,9 +18,10 @@ type PaginatedResponse struct {
 }
 
 type PaginationMeta struct {
-	Total  int64 `json:"total"`
-	Offset int   `json:"offset"`
-	Limit  int   `json:"limit"`
+	Total      int64  `json:"total"`
+	Offset     int    `json:"offset"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"` // Set when more results follow a cursor-paginated page
 }
 
 type IDRequest struct {
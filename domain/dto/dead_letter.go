@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dead-letter queue page sizes
+const (
+	DefaultDeadLetterPageSize = 50
+	MaxDeadLetterPageSize     = 200
+)
+
+// DeadLetterQuery holds the filters of GET /admin/dead-letters. Results are oldest first.
+type DeadLetterQuery struct {
+	Topic  string `query:"topic" validate:"omitempty,max=100"`
+	Source string `query:"source" validate:"omitempty,oneof=sync outbox"`
+	UserID string `query:"userId" validate:"omitempty,uuid"`
+	Offset int    `query:"offset" validate:"min=0"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+type DeadLetterResponse struct {
+	ID            uuid.UUID       `json:"id"` // Event ID, kept when the event is retried
+	UserID        uuid.UUID       `json:"userId"`
+	Topic         string          `json:"topic"`
+	Source        string          `json:"source"` // "sync" or "outbox"
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"lastError,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"` // Only set when a single event is inspected
+	CreatedAt     time.Time       `json:"createdAt"`
+	LastAttemptAt time.Time       `json:"lastAttemptAt"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// EventSchemaResponse describes one published event type
+type EventSchemaResponse struct {
+	Type             string                       `json:"type"` // CloudEvents type
+	Version          int                          `json:"version"`
+	Subject          string                       `json:"subject"` // NATS subject of the current version
+	DataSchema       string                       `json:"dataschema"`
+	PreviousVersions []EventSchemaVersionResponse `json:"previousVersions"`        // Still published for pinned consumers
+	LegacySubject    string                       `json:"legacySubject,omitempty"` // Flat payloads, while enabled
+}
+
+type EventSchemaVersionResponse struct {
+	Version    int    `json:"version"`
+	Subject    string `json:"subject"`
+	DataSchema string `json:"dataschema"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StartImpersonationRequest struct {
+	Reason          string `json:"reason" validate:"required,min=1,max=500"`
+	DurationMinutes int    `json:"durationMinutes" validate:"omitempty,min=1"` // Capped at IMPERSONATION_TTL
+}
+
+type ImpersonationSessionResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	ActorID      uuid.UUID  `json:"actorId"`
+	TargetUserID uuid.UUID  `json:"targetUserId"`
+	Reason       string     `json:"reason"`
+	IPAddress    string     `json:"ipAddress,omitempty"`
+	UserAgent    string     `json:"userAgent,omitempty"`
+	StartedAt    time.Time  `json:"startedAt"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	EndedAt      *time.Time `json:"endedAt"`
+	EndedBy      *uuid.UUID `json:"endedBy,omitempty"`
+	EndReason    string     `json:"endReason,omitempty"`
+	Active       bool       `json:"active"`
+}
+
+// ImpersonationResponse carries a non-refreshable access token for the impersonated user
+type ImpersonationResponse struct {
+	AccessToken string                       `json:"accessToken"`
+	TokenType   string                       `json:"tokenType"`
+	ExpiresIn   int64                        `json:"expiresIn"`
+	Session     ImpersonationSessionResponse `json:"session"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type LockoutStatusResponse struct {
+	Locked         bool       `json:"locked"`
+	LockedUntil    *time.Time `json:"lockedUntil"`
+	FailedAttempts int64      `json:"failedAttempts"`
+	LastFailureAt  *time.Time `json:"lastFailureAt"`
+}
@@ -1,7 +1,11 @@
 package dto
 
 import (
+	"encoding/json"
 	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 func UserToUserResponse(user *models.User) *UserResponse {
@@ -43,4 +47,278 @@ func UpdateUserRequestToUser(req *UpdateUserRequest) *models.User {
 		DisplayName: req.DisplayName,
 		Avatar:      req.Avatar,
 	}
-}
\ No newline at end of file
+}
+func RoleToRoleResponse(role *models.Role) *RoleResponse {
+	if role == nil {
+		return nil
+	}
+
+	permissions := make([]string, len(role.Permissions))
+	for i, permission := range role.Permissions {
+		permissions[i] = permission.Name
+	}
+
+	return &RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+func PermissionToPermissionResponse(permission *models.Permission) *PermissionResponse {
+	if permission == nil {
+		return nil
+	}
+
+	return &PermissionResponse{
+		ID:          permission.ID,
+		Name:        permission.Name,
+		Description: permission.Description,
+	}
+}
+
+func OrganizationToOrganizationResponse(org *models.Organization, role string) *OrganizationResponse {
+	if org == nil {
+		return nil
+	}
+
+	return &OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		Role:      role,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
+
+func MemberToOrganizationMemberResponse(member *models.OrganizationMember) *OrganizationMemberResponse {
+	if member == nil {
+		return nil
+	}
+
+	displayName := member.User.DisplayName
+	if displayName == "" {
+		displayName = member.User.Username
+	}
+
+	return &OrganizationMemberResponse{
+		UserID:      member.UserID,
+		Email:       member.User.Email,
+		Username:    member.User.Username,
+		DisplayName: displayName,
+		Role:        member.Role,
+		JoinedAt:    member.CreatedAt,
+	}
+}
+
+func InvitationToInvitationResponse(invitation *models.OrganizationInvitation) *InvitationResponse {
+	if invitation == nil {
+		return nil
+	}
+
+	return &InvitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+}
+
+func PersonalAccessTokenToResponse(token *models.PersonalAccessToken) *PersonalAccessTokenResponse {
+	if token == nil {
+		return nil
+	}
+
+	return &PersonalAccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		RevokedAt:   token.RevokedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+func UserSessionToResponse(session *models.UserSession, currentSessionID uuid.UUID) *SessionResponse {
+	if session == nil {
+		return nil
+	}
+
+	return &SessionResponse{
+		ID:         session.ID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		Location:   session.Location,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentSessionID,
+	}
+}
+
+func UserToAdminUserResponse(user *models.User) *AdminUserResponse {
+	if user == nil {
+		return nil
+	}
+
+	return &AdminUserResponse{
+		UserResponse:  *UserToUserResponse(user),
+		EmailVerified: user.EmailVerified,
+		IsOAuthUser:   user.IsOAuthUser,
+		OAuthProvider: user.OAuthProvider,
+		HasPassword:   user.Password != nil,
+		LastLoginAt:   user.LastLoginAt,
+	}
+}
+
+func SuspensionToSuspensionResponse(suspension *models.UserSuspension) *SuspensionResponse {
+	if suspension == nil {
+		return nil
+	}
+
+	return &SuspensionResponse{
+		ID:          suspension.ID,
+		UserID:      suspension.UserID,
+		Reason:      suspension.Reason,
+		Notes:       suspension.Notes,
+		SuspendedBy: suspension.SuspendedBy,
+		StartsAt:    suspension.StartsAt,
+		EndsAt:      suspension.EndsAt,
+		LiftedAt:    suspension.LiftedAt,
+		LiftedBy:    suspension.LiftedBy,
+		LiftReason:  suspension.LiftReason,
+		Active:      suspension.IsActive(time.Now()),
+	}
+}
+
+func ImpersonationSessionToResponse(session *models.ImpersonationSession) *ImpersonationSessionResponse {
+	if session == nil {
+		return nil
+	}
+
+	return &ImpersonationSessionResponse{
+		ID:           session.ID,
+		ActorID:      session.ActorID,
+		TargetUserID: session.TargetUserID,
+		Reason:       session.Reason,
+		IPAddress:    session.IPAddress,
+		UserAgent:    session.UserAgent,
+		StartedAt:    session.StartedAt,
+		ExpiresAt:    session.ExpiresAt,
+		EndedAt:      session.EndedAt,
+		EndedBy:      session.EndedBy,
+		EndReason:    session.EndReason,
+		Active:       session.IsActive(time.Now()),
+	}
+}
+
+func AuditEventToResponse(event *models.AuditEvent) *AuditEventResponse {
+	if event == nil {
+		return nil
+	}
+
+	return &AuditEventResponse{
+		ID:             event.ID,
+		Type:           event.EventType,
+		Outcome:        event.Outcome,
+		ActorID:        event.ActorID,
+		ImpersonatorID: event.ImpersonatorID,
+		SubjectID:      event.SubjectID,
+		IPAddress:      event.IPAddress,
+		UserAgent:      event.UserAgent,
+		RequestID:      event.RequestID,
+		Metadata:       event.Metadata,
+		CreatedAt:      event.CreatedAt,
+	}
+}
+
+// AuditEventToSecurityActivity maps an event for display to the user it concerns
+func AuditEventToSecurityActivity(event *models.AuditEvent, userID uuid.UUID) *SecurityActivityResponse {
+	if event == nil {
+		return nil
+	}
+
+	return &SecurityActivityResponse{
+		Type:      event.EventType,
+		Outcome:   event.Outcome,
+		ByAdmin:   event.ImpersonatorID != nil || (event.ActorID != nil && *event.ActorID != userID),
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// DeadLetterEventToResponse maps a dead-letter event; the payload is included only when withPayload is set
+func DeadLetterEventToResponse(event *models.DeadLetterEvent, withPayload bool) *DeadLetterResponse {
+	response := &DeadLetterResponse{
+		ID:            event.ID,
+		UserID:        event.AggregateID,
+		Topic:         event.Topic,
+		Source:        event.Source,
+		Attempts:      event.Attempts,
+		LastError:     event.LastError,
+		CreatedAt:     event.CreatedAt,
+		LastAttemptAt: event.LastAttemptAt,
+	}
+	if withPayload {
+		response.Payload = json.RawMessage(event.Payload)
+	}
+	return response
+}
+
+// WebhookEndpointToResponse maps an endpoint; the secret is only included when withSecret is set
+func WebhookEndpointToResponse(endpoint *models.WebhookEndpoint, withSecret bool) *WebhookResponse {
+	response := &WebhookResponse{
+		ID:                  endpoint.ID,
+		URL:                 endpoint.URL,
+		Description:         endpoint.Description,
+		EventTypes:          endpoint.EventTypeList(),
+		Active:              endpoint.IsActive,
+		ConsecutiveFailures: endpoint.ConsecutiveFailures,
+		DisabledAt:          endpoint.DisabledAt,
+		DisabledReason:      endpoint.DisabledReason,
+		CreatedAt:           endpoint.CreatedAt,
+		UpdatedAt:           endpoint.UpdatedAt,
+	}
+	if withSecret {
+		response.Secret = endpoint.Secret
+	}
+	return response
+}
+
+func WebhookDeliveryToResponse(delivery *models.WebhookDelivery, withPayload bool) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		LastResponse:   delivery.LastResponse,
+		LastDurationMs: delivery.LastDurationMs,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if withPayload {
+		response.Payload = json.RawMessage(delivery.Payload)
+	}
+	return response
+}
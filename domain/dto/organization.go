@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+	Slug string `json:"slug" validate:"omitempty,min=2,max=100"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Role      string    `json:"role,omitempty"` // Caller's role in the organization
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID      uuid.UUID `json:"userId"`
+	Email       string    `json:"email"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+type InvitationResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	InvitedBy      uuid.UUID `json:"invitedBy"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type SwitchOrganizationResponse struct {
+	Token          string    `json:"token"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Role           string    `json:"role"`
+}
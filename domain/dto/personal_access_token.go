@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,min=1,max=100"`
+	ExpiresInDays *int     `json:"expiresInDays" validate:"omitempty,min=1,max=365"` // Defaults to 90 days
+}
+
+type PersonalAccessTokenResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// CreatePersonalAccessTokenResponse includes the plaintext token, which is only shown once
+type CreatePersonalAccessTokenResponse struct {
+	Token string `json:"token"`
+	PersonalAccessTokenResponse
+}
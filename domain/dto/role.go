@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,min=1,max=100"`
+}
+
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,min=1,max=100"` // Replaces the role's permissions when provided
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,min=2,max=50"`
+}
+
+type RoleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsSystem    bool      `json:"isSystem"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type PermissionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type UserRolesResponse struct {
+	UserID      uuid.UUID      `json:"userId"`
+	PrimaryRole string         `json:"primaryRole"`
+	Roles       []RoleResponse `json:"roles"`
+	Permissions []string       `json:"permissions"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" validate:"required,min=1,max=500"`
+	Notes  string     `json:"notes" validate:"omitempty,max=2000"`
+	EndsAt *time.Time `json:"endsAt"` // Omit for an indefinite ban
+}
+
+type LiftSuspensionRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+type SuspensionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"userId"`
+	Reason      string     `json:"reason"`
+	Notes       string     `json:"notes,omitempty"`
+	SuspendedBy uuid.UUID  `json:"suspendedBy"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      *time.Time `json:"endsAt"`
+	LiftedAt    *time.Time `json:"liftedAt"`
+	LiftedBy    *uuid.UUID `json:"liftedBy,omitempty"`
+	LiftReason  string     `json:"liftReason,omitempty"`
+	Active      bool       `json:"active"`
+}
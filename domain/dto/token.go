@@ -0,0 +1,39 @@
+package dto
+
+// Token Introspection (RFC 7662) and Revocation (RFC 7009) DTOs
+// Field names follow the RFCs (snake_case, form-encoded requests)
+
+type IntrospectionRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint" validate:"omitempty,oneof=access_token refresh_token"`
+}
+
+type IntrospectionResponse struct {
+	Active      bool                `json:"active"`
+	Scope       string              `json:"scope,omitempty"`
+	ClientID    string              `json:"client_id,omitempty"`
+	Username    string              `json:"username,omitempty"`
+	TokenType   string              `json:"token_type,omitempty"` // "access_token" | "personal_access_token"
+	Exp         int64               `json:"exp,omitempty"`
+	Iat         int64               `json:"iat,omitempty"`
+	Sub         string              `json:"sub,omitempty"`
+	Jti         string              `json:"jti,omitempty"`
+	Email       string              `json:"email,omitempty"`
+	Role        string              `json:"role,omitempty"`
+	Roles       []string            `json:"roles,omitempty"`
+	Permissions []string            `json:"permissions,omitempty"`
+	OrgID       string              `json:"org_id,omitempty"`
+	OrgRole     string              `json:"org_role,omitempty"`
+	Act         *IntrospectionActor `json:"act,omitempty"` // Present on impersonation tokens
+}
+
+// IntrospectionActor identifies the administrator behind an impersonation token (RFC 8693 section 4.1)
+type IntrospectionActor struct {
+	Sub      string `json:"sub"`
+	Username string `json:"username,omitempty"`
+}
+
+type RevocationRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint" validate:"omitempty,oneof=access_token refresh_token"`
+}
@@ -9,7 +9,7 @@ import (
 type CreateUserRequest struct {
 	Email       string `json:"email" validate:"required,email,max=255"`
 	Username    string `json:"username" validate:"required,min=3,max=20,alphanum"`
-	Password    string `json:"password" validate:"required,min=8,max=72"`
+	Password    string `json:"password" validate:"required,max=256"`
 	DisplayName string `json:"displayName" validate:"required,min=1,max=100"`
 }
 
@@ -30,6 +30,31 @@ type UserResponse struct {
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
+// Page sizes for user listing
+const (
+	DefaultUserPageSize = 10
+	MaxUserPageSize     = 100
+)
+
+// UserListQuery holds the filters of GET /users. Timestamps are RFC 3339;
+// sort takes a key optionally prefixed with "-" for descending order.
+// Pass nextCursor from the previous page as cursor for stable keyset pagination.
+type UserListQuery struct {
+	Q             string `query:"q" validate:"omitempty,max=100"`
+	Role          string `query:"role" validate:"omitempty,max=50"`
+	Active        *bool  `query:"active"`
+	Provider      string `query:"provider" validate:"omitempty,oneof=local google facebook line"`
+	EmailVerified *bool  `query:"emailVerified"`
+	CreatedFrom   string `query:"createdFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	CreatedTo     string `query:"createdTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	LastLoginFrom string `query:"lastLoginFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	LastLoginTo   string `query:"lastLoginTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=createdAt -createdAt updatedAt -updatedAt lastLoginAt -lastLoginAt email -email username -username"`
+	Cursor        string `query:"cursor" validate:"omitempty,max=512"`
+	Offset        int    `query:"offset" validate:"min=0"`
+	Limit         int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
 type UserListResponse struct {
 	Users []UserResponse `json:"users"`
 	Meta  PaginationMeta `json:"meta"`
@@ -37,6 +62,32 @@ type UserListResponse struct {
 
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"currentPassword" validate:"required"`
-	NewPassword     string `json:"newPassword" validate:"required,min=8,max=72"`
+	NewPassword     string `json:"newPassword" validate:"required,max=256"`
 	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=NewPassword"`
 }
+
+type AdminUpdateUserRequest struct {
+	Email         *string `json:"email" validate:"omitempty,email,max=255"`
+	Username      *string `json:"username" validate:"omitempty,min=3,max=20,alphanum"`
+	DisplayName   *string `json:"displayName" validate:"omitempty,min=1,max=100"`
+	Avatar        *string `json:"avatar" validate:"omitempty,url,max=500"`
+	EmailVerified *bool   `json:"emailVerified"`
+}
+
+type ChangeUserRoleRequest struct {
+	Role string `json:"role" validate:"required,min=2,max=50"`
+}
+
+type DeactivateUserRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// AdminUserResponse extends UserResponse with account details visible to administrators
+type AdminUserResponse struct {
+	UserResponse
+	EmailVerified bool       `json:"emailVerified"`
+	IsOAuthUser   bool       `json:"isOAuthUser"`
+	OAuthProvider string     `json:"oauthProvider,omitempty"`
+	HasPassword   bool       `json:"hasPassword"`
+	LastLoginAt   *time.Time `json:"lastLoginAt"`
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery log page sizes
+const (
+	DefaultWebhookDeliveryPageSize = 50
+	MaxWebhookDeliveryPageSize     = 200
+)
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,startswith=http,max=500"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"eventTypes" validate:"required,min=1,dive,min=1,max=100"` // e.g. "user.created", "user.*" or "*"
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=100"`              // Generated when empty
+}
+
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,url,startswith=http,max=500"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"eventTypes" validate:"omitempty,min=1,dive,min=1,max=100"` // Replaces the subscribed types when provided
+	Secret      *string  `json:"secret" validate:"omitempty,min=16,max=100"`               // Rotates the signing secret
+	Active      *bool    `json:"active"`                                                   // true re-enables a disabled endpoint
+}
+
+type WebhookResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	URL                 string     `json:"url"`
+	Description         string     `json:"description,omitempty"`
+	EventTypes          []string   `json:"eventTypes"`
+	Secret              string     `json:"secret,omitempty"` // Only returned when the endpoint is created or the secret is set
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	DisabledReason      string     `json:"disabledReason,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// WebhookDeliveryQuery holds the filters of GET /admin/webhooks/:id/deliveries. Results are newest first.
+type WebhookDeliveryQuery struct {
+	Status    string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	EventType string `query:"eventType" validate:"omitempty,max=100"`
+	Offset    int    `query:"offset" validate:"min=0"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	EndpointID     uuid.UUID       `json:"endpointId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"` // "pending", "succeeded" or "failed"
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"` // Set while pending
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	LastResponse   string          `json:"lastResponse,omitempty"`
+	LastDurationMs int64           `json:"lastDurationMs,omitempty"`
+	RedeliveryOf   *uuid.UUID      `json:"redeliveryOf,omitempty"`
+	Payload        json.RawMessage `json:"payload,omitempty"` // Only set when a single delivery is inspected
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
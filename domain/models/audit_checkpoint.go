@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditCheckpoint is a signed statement of the audit chain head at a point in time.
+// Checkpoints let a verifier detect truncation of the newest events, which the chain alone cannot.
+type AuditCheckpoint struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Sequence  int64     `gorm:"not null;uniqueIndex"` // Sequence of the last event covered
+	EventID   uuid.UUID `gorm:"type:uuid;not null"`
+	Hash      string    `gorm:"not null;size:64"` // Hash of that event
+	KeyID     string    `gorm:"not null;size:16"` // Identifies the signing key
+	Signature string    `gorm:"not null;size:128"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (AuditCheckpoint) TableName() string {
+	return "audit_checkpoints"
+}
+
+// BeforeCreate hook to generate UUID
+func (c *AuditCheckpoint) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// SigningPayload returns the bytes covered by the checkpoint signature
+func (c *AuditCheckpoint) SigningPayload() []byte {
+	return []byte(fmt.Sprintf("audit-checkpoint:v1:%d:%s:%s:%s",
+		c.Sequence, c.EventID, c.Hash, c.CreatedAt.UTC().Format(time.RFC3339Nano)))
+}
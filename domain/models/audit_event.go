@@ -0,0 +1,142 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AuditEvent is one entry of the append-only security audit log.
+// Events reference users by ID only, without foreign keys, so they outlive account deletion.
+type AuditEvent struct {
+	ID             uuid.UUID         `gorm:"primaryKey;type:uuid"`
+	EventType      string            `gorm:"not null;size:100;index"`
+	Outcome        string            `gorm:"not null;size:20"`
+	ActorID        *uuid.UUID        `gorm:"type:uuid;index"` // Who performed the action (nil when anonymous)
+	ImpersonatorID *uuid.UUID        `gorm:"type:uuid"`       // Administrator acting as ActorID, if any
+	SubjectID      *uuid.UUID        `gorm:"type:uuid;index"` // User the action was performed on
+	IPAddress      string            `gorm:"size:64"`
+	UserAgent      string            `gorm:"size:500"`
+	RequestID      string            `gorm:"size:100"`
+	Metadata       datatypes.JSONMap `gorm:"type:jsonb"`
+	CreatedAt      time.Time         `gorm:"not null;index"`
+
+	// Hash chain: Hash covers the event's content and PrevHash, the Hash of the event
+	// with the next lower Sequence. Editing or removing any event breaks every later link.
+	Sequence int64  `gorm:"autoIncrement;uniqueIndex"`
+	PrevHash string `gorm:"size:64"`
+	Hash     string `gorm:"size:64"`
+}
+
+// Audit outcomes
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// Audit event types ("area.subject.verb")
+const (
+	AuditLoginSucceeded         = "auth.login.succeeded"
+	AuditLoginFailed            = "auth.login.failed"
+	AuditPasswordResetRequested = "auth.password.reset_requested"
+	AuditPasswordReset          = "auth.password.reset"
+	AuditPasswordChanged        = "auth.password.changed"
+	AuditProviderLinked         = "auth.provider.linked"
+	AuditProviderUnlinked       = "auth.provider.unlinked"
+	AuditAccountLocked          = "auth.account.locked"
+	AuditLoginNewDevice         = "auth.login.new_device"
+	AuditLoginDenied            = "auth.login.denied"
+	AuditLoginRiskAssessed      = "auth.login.risk_assessed"
+
+	AuditTokenRevoked        = "token.revoked"
+	AuditTokensRevokedAll    = "token.revoked_all"
+	AuditSessionRevoked      = "token.session.revoked"
+	AuditPersonalTokenCreate = "token.personal.created"
+	AuditPersonalTokenRevoke = "token.personal.revoked"
+
+	AuditUserUpdated        = "admin.user.updated"
+	AuditUserDeleted        = "admin.user.deleted"
+	AuditUserActivated      = "admin.user.activated"
+	AuditUserDeactivated    = "admin.user.deactivated"
+	AuditUserRoleChanged    = "admin.user.role_changed"
+	AuditUserPasswordReset  = "admin.user.password_reset_forced"
+	AuditUserSuspended      = "admin.user.suspended"
+	AuditUserReinstated     = "admin.user.reinstated"
+	AuditUserUnlocked       = "admin.user.unlocked"
+	AuditImpersonationStart = "admin.impersonation.started"
+	AuditImpersonationEnd   = "admin.impersonation.ended"
+
+	AuditRoleCreated  = "admin.role.created"
+	AuditRoleUpdated  = "admin.role.updated"
+	AuditRoleDeleted  = "admin.role.deleted"
+	AuditRoleAssigned = "admin.role.assigned"
+	AuditRoleRemoved  = "admin.role.removed"
+
+	AuditDeadLetterRetried   = "admin.dead_letter.retried"
+	AuditDeadLetterDiscarded = "admin.dead_letter.discarded"
+
+	AuditWebhookCreated     = "admin.webhook.created"
+	AuditWebhookUpdated     = "admin.webhook.updated"
+	AuditWebhookDeleted     = "admin.webhook.deleted"
+	AuditWebhookRedelivered = "admin.webhook.redelivered"
+	AuditWebhookDisabled    = "admin.webhook.disabled" // Disabled automatically after repeated failures
+)
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// BeforeCreate hook to generate UUID
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// auditHashInput is the canonical form hashed into the chain. Field order is part of the format.
+type auditHashInput struct {
+	PrevHash       string                 `json:"prev_hash"`
+	ID             uuid.UUID              `json:"id"`
+	EventType      string                 `json:"event_type"`
+	Outcome        string                 `json:"outcome"`
+	ActorID        *uuid.UUID             `json:"actor_id"`
+	ImpersonatorID *uuid.UUID             `json:"impersonator_id"`
+	SubjectID      *uuid.UUID             `json:"subject_id"`
+	IPAddress      string                 `json:"ip_address"`
+	UserAgent      string                 `json:"user_agent"`
+	RequestID      string                 `json:"request_id"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	CreatedAt      string                 `json:"created_at"`
+}
+
+// ComputeHash returns the hex SHA-256 chain hash of the event as stored.
+// CreatedAt must already be at database (microsecond) precision and Metadata in its
+// JSON-decoded form, so that the hash is reproducible from the stored row.
+func (e *AuditEvent) ComputeHash() (string, error) {
+	payload, err := json.Marshal(auditHashInput{
+		PrevHash:       e.PrevHash,
+		ID:             e.ID,
+		EventType:      e.EventType,
+		Outcome:        e.Outcome,
+		ActorID:        e.ActorID,
+		ImpersonatorID: e.ImpersonatorID,
+		SubjectID:      e.SubjectID,
+		IPAddress:      e.IPAddress,
+		UserAgent:      e.UserAgent,
+		RequestID:      e.RequestID,
+		Metadata:       e.Metadata,
+		CreatedAt:      e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// DeadLetterEvent is an event that could not be delivered after all attempts.
+// It keeps the original event ID so a retry is deduplicated by consumers that did receive it.
+type DeadLetterEvent struct {
+	ID            uuid.UUID      `gorm:"primaryKey;type:uuid"` // Event ID of the undelivered event
+	AggregateID   uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Topic         string         `gorm:"not null;size:100;index"`
+	Payload       datatypes.JSON `gorm:"type:jsonb;not null"`
+	Source        string         `gorm:"not null;size:20"`
+	Attempts      int            `gorm:"not null"`
+	LastError     string         `gorm:"size:500"`
+	CreatedAt     time.Time      `gorm:"not null;index"`
+	LastAttemptAt time.Time      `gorm:"not null"`
+}
+
+// Dead-letter sources
+const (
+	DeadLetterSourceSync   = "sync"   // Direct delivery by SyncUserAsync
+	DeadLetterSourceOutbox = "outbox" // Outbox relay, after the maximum number of attempts
+)
+
+func (DeadLetterEvent) TableName() string {
+	return "dead_letter_events"
+}
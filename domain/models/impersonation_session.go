@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpersonationSession is the audit record of an administrator acting as another user.
+// Its ID doubles as the jti of the impersonation token, so ending the session revokes the token.
+type ImpersonationSession struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ActorID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TargetUserID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Reason       string     `gorm:"not null;size:500"`
+	IPAddress    string     `gorm:"size:64"`
+	UserAgent    string     `gorm:"size:500"`
+	StartedAt    time.Time  `gorm:"not null"`
+	ExpiresAt    time.Time  `gorm:"not null;index"`
+	EndedAt      *time.Time `gorm:"index"`
+	EndedBy      *uuid.UUID `gorm:"type:uuid"` // nil when the session expired
+	EndReason    string     `gorm:"size:50"`   // "ended" | "terminated" | "expired"
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Actor      User `gorm:"foreignKey:ActorID;constraint:OnDelete:CASCADE"`
+	TargetUser User `gorm:"foreignKey:TargetUserID;constraint:OnDelete:CASCADE"`
+}
+
+// Impersonation end reasons
+const (
+	ImpersonationEnded      = "ended"      // Ended by the impersonating administrator
+	ImpersonationTerminated = "terminated" // Ended by another administrator
+	ImpersonationExpired    = "expired"
+)
+
+func (ImpersonationSession) TableName() string {
+	return "impersonation_sessions"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *ImpersonationSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the session is open at the given time
+func (s *ImpersonationSession) IsActive(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
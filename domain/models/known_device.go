@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KnownDevice is a device and country combination the user has signed in from.
+// A sign-in matching no fingerprint, or no country, of the user's known devices triggers a LoginAlert.
+type KnownDevice struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_known_devices_user_device"`
+	Fingerprint string    `gorm:"size:64;not null;uniqueIndex:idx_known_devices_user_device"` // SHA-256 of the device cookie and browser/OS
+	Country     string    `gorm:"size:8;not null;default:'';uniqueIndex:idx_known_devices_user_device"`
+	DeviceName  string    `gorm:"size:100"`
+	IPAddress   string    `gorm:"size:64"`
+	FirstSeenAt time.Time `gorm:"not null"`
+	LastSeenAt  time.Time `gorm:"not null"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (KnownDevice) TableName() string {
+	return "known_devices"
+}
+
+// BeforeCreate hook to generate UUID
+func (d *KnownDevice) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// LoginAlert records a notification about an unfamiliar sign-in. The emailed
+// "this wasn't me" link carries a single-use token; only its hash is stored.
+type LoginAlert struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash  string    `gorm:"uniqueIndex;not null;size:64"`
+	DeviceName string    `gorm:"size:100"`
+	IPAddress  string    `gorm:"size:64"`
+	Location   string    `gorm:"size:100"`
+	NewDevice  bool      `gorm:"not null"`
+	NewCountry bool      `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	UsedAt     *time.Time
+	CreatedAt  time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (LoginAlert) TableName() string {
+	return "login_alerts"
+}
+
+// BeforeCreate hook to generate UUID
+func (a *LoginAlert) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsUsable reports whether the "this wasn't me" link is unused and unexpired
+func (a *LoginAlert) IsUsable(now time.Time) bool {
+	return a.UsedAt == nil && now.Before(a.ExpiresAt)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Organization roles (per-membership, independent of global RBAC roles)
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// OrgRoleRank orders organization roles from least to most privileged
+var OrgRoleRank = map[string]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+type Organization struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name      string    `gorm:"not null;size:100"`
+	Slug      string    `gorm:"uniqueIndex;not null;size:100"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// BeforeCreate hook to generate UUID
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID         uuid.UUID  `gorm:"primaryKey;type:uuid;index"`
+	Role           string     `gorm:"not null;size:20;default:'member'"`
+	LastActiveAt   *time.Time // Last time this organization was the user's active org
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Relationships
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	User         User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (OrganizationMember) TableName() string {
+	return "organization_members"
+}
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID `gorm:"primaryKey;type:uuid"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email          string    `gorm:"not null;size:255;index"`
+	Role           string    `gorm:"not null;size:20"`
+	TokenHash      string    `gorm:"uniqueIndex;not null;size:64"` // SHA-256 of the invitation token
+	InvitedBy      uuid.UUID `gorm:"type:uuid;not null"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	AcceptedAt     *time.Time
+	AcceptedBy     *uuid.UUID `gorm:"type:uuid"`
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+
+	// Relationship
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+}
+
+func (OrganizationInvitation) TableName() string {
+	return "organization_invitations"
+}
+
+// BeforeCreate hook to generate UUID
+func (i *OrganizationInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsPending reports whether the invitation can still be accepted
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
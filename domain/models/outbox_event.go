@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is an event written in the same transaction as the change it describes.
+// The outbox relay publishes pending events in Sequence order per aggregate and marks them
+// published; delivery is at-least-once, so consumers deduplicate on ID.
+type OutboxEvent struct {
+	ID            uuid.UUID      `gorm:"primaryKey;type:uuid"` // Stable event ID, also sent as event_id in the payload
+	Sequence      int64          `gorm:"autoIncrement;uniqueIndex"`
+	AggregateID   uuid.UUID      `gorm:"type:uuid;not null;index"` // Events of one aggregate (user) are published in order
+	Topic         string         `gorm:"not null;size:100"`        // Relative to the NATS subject, e.g. "created"
+	Payload       datatypes.JSON `gorm:"type:jsonb;not null"`
+	Attempts      int            `gorm:"not null;default:0"`
+	LastError     string         `gorm:"size:500"`
+	NextAttemptAt time.Time      `gorm:"not null"`
+	PublishedAt   *time.Time     `gorm:"index"`
+	CreatedAt     time.Time      `gorm:"not null;index"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// BeforeCreate hook to generate UUID
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = e.CreatedAt
+	}
+	return nil
+}
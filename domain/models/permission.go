@@ -0,0 +1,40 @@
+package models
+
+// System role names
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permission names ("resource:action")
+const (
+	PermUsersRead        = "users:read"
+	PermUsersWrite       = "users:write"
+	PermUsersDelete      = "users:delete"
+	PermUsersSuspend     = "users:suspend"
+	PermUsersImpersonate = "users:impersonate"
+	PermRolesRead        = "roles:read"
+	PermRolesWrite       = "roles:write"
+	PermAuditRead        = "audit:read"
+	PermEventsRead       = "events:read"
+	PermEventsWrite      = "events:write"
+	PermWebhooksRead     = "webhooks:read"
+	PermWebhooksWrite    = "webhooks:write"
+)
+
+// PermissionCatalogue lists every permission known to the service with its description.
+// It is seeded into the permissions table on startup; the admin role receives all of them.
+var PermissionCatalogue = map[string]string{
+	PermUsersRead:        "List and view user accounts",
+	PermUsersWrite:       "Edit user accounts",
+	PermUsersDelete:      "Delete user accounts",
+	PermUsersSuspend:     "Suspend and reinstate user accounts",
+	PermUsersImpersonate: "Sign in as another user for support and debugging",
+	PermRolesRead:        "View roles, permissions and assignments",
+	PermRolesWrite:       "Manage roles, permissions and assignments",
+	PermAuditRead:        "View the security audit log",
+	PermEventsRead:       "View undelivered events in the dead-letter queue",
+	PermEventsWrite:      "Retry and discard undelivered events",
+	PermWebhooksRead:     "View webhook endpoints and delivery logs",
+	PermWebhooksWrite:    "Manage webhook endpoints and redeliver webhooks",
+}
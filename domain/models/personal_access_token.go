@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessToken is a long-lived, user-managed credential for scripts and integrations.
+// Only the SHA-256 hash of the token is stored; TokenPrefix is kept so users can recognise it.
+type PersonalAccessToken struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name        string     `gorm:"not null;size:100"`
+	TokenPrefix string     `gorm:"not null;size:20"`
+	TokenHash   string     `gorm:"uniqueIndex;not null;size:64"`
+	Scopes      string     `gorm:"type:text;not null"` // Space-separated permission names
+	ExpiresAt   *time.Time `gorm:"index"`
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// BeforeCreate hook to generate UUID
+func (t *PersonalAccessToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// ScopeList returns the token's scopes as a slice
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// IsActive reports whether the token is neither revoked nor expired
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
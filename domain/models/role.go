@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Role groups permissions. System roles ("admin", "user") are seeded on startup
+// and match the legacy User.Role column, which is treated as an implicit assignment.
+type Role struct {
+	ID          uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	Name        string       `gorm:"uniqueIndex;not null;size:50"`
+	Description string       `gorm:"size:255"`
+	IsSystem    bool         `gorm:"default:false"` // System roles cannot be deleted or renamed
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+// BeforeCreate hook to generate UUID
+func (r *Role) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// Permission is a "resource:action" capability such as "users:read"
+type Permission struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name        string    `gorm:"uniqueIndex;not null;size:100"`
+	Description string    `gorm:"size:255"`
+	CreatedAt   time.Time
+}
+
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// BeforeCreate hook to generate UUID
+func (p *Permission) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// UserRole assigns a role to a user (a user may hold many roles)
+type UserRole struct {
+	UserID     uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	RoleID     uuid.UUID  `gorm:"primaryKey;type:uuid;index"`
+	AssignedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Role Role `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
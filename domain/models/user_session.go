@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserSession is one sign-in on one device. Tokens issued for it carry its ID in the sid claim,
+// so revoking the session invalidates every access token it produced.
+type UserSession struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DeviceName    string     `gorm:"size:100"` // Derived from the user agent, e.g. "Chrome on macOS"
+	UserAgent     string     `gorm:"size:500"`
+	IPAddress     string     `gorm:"size:64"`
+	Location      string     `gorm:"size:100"` // Approximate, from edge proxy geolocation headers
+	CreatedAt     time.Time  `gorm:"not null"`
+	LastSeenAt    time.Time  `gorm:"not null"`
+	ExpiresAt     time.Time  `gorm:"not null;index"` // Extended whenever the user switches organization
+	RevokedAt     *time.Time `gorm:"index"`
+	RevokedReason string     `gorm:"size:50"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// Session revocation reasons
+const (
+	SessionRevokedByUser  = "signed_out"    // Revoked from the session list
+	SessionRevokedAll     = "revoked_all"   // Every session of the user was revoked
+	SessionRevokedByToken = "token_revoked" // A token of the session was revoked (RFC 7009)
+)
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *UserSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether tokens of the session are accepted at the given time
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserSuspension records a temporary suspension or, when EndsAt is nil, an indefinite ban.
+// Records are never deleted; lifting one sets LiftedAt so the history is preserved.
+type UserSuspension struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Reason      string     `gorm:"not null;size:500"`
+	Notes       string     `gorm:"type:text"` // Internal notes, not shown to the user
+	SuspendedBy uuid.UUID  `gorm:"type:uuid;not null"`
+	StartsAt    time.Time  `gorm:"not null"`
+	EndsAt      *time.Time `gorm:"index"`
+	LiftedAt    *time.Time `gorm:"index"`
+	LiftedBy    *uuid.UUID `gorm:"type:uuid"` // nil when lifted automatically on expiry
+	LiftReason  string     `gorm:"size:500"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (UserSuspension) TableName() string {
+	return "user_suspensions"
+}
+
+// BeforeCreate hook to generate UUID
+func (s *UserSuspension) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the suspension is in force at the given time
+func (s *UserSuspension) IsActive(now time.Time) bool {
+	if s.LiftedAt != nil || now.Before(s.StartsAt) {
+		return false
+	}
+	return s.EndsAt == nil || now.Before(*s.EndsAt)
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookEndpoint is a URL that receives events as signed HTTP POST requests.
+// The secret is stored as-is because every delivery is signed with it.
+type WebhookEndpoint struct {
+	ID                  uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	URL                 string     `gorm:"not null;size:500"`
+	Description         string     `gorm:"size:255"`
+	EventTypes          string     `gorm:"type:text;not null"` // Space-separated event types; "*" and "user.*" style prefixes match several
+	Secret              string     `gorm:"not null;size:100"`
+	IsActive            bool       `gorm:"not null;default:true;index"`
+	ConsecutiveFailures int        `gorm:"not null;default:0"` // Failed attempts since the last successful one
+	DisabledAt          *time.Time // Set when the endpoint was disabled automatically
+	DisabledReason      string     `gorm:"size:500"`
+	CreatedBy           *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// BeforeCreate hook to generate UUID
+func (e *WebhookEndpoint) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// EventTypeList returns the subscribed event types as a slice
+func (e *WebhookEndpoint) EventTypeList() []string {
+	return strings.Fields(e.EventTypes)
+}
+
+// Subscribes reports whether the endpoint receives events of the given type
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	for _, pattern := range e.EventTypeList() {
+		if pattern == "*" || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its next attempt
+	WebhookDeliverySucceeded = "succeeded" // The endpoint answered with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // Every attempt failed; can be redelivered manually
+)
+
+// WebhookDelivery is one event sent to one endpoint, retried with backoff until it succeeds or
+// runs out of attempts. A manual redelivery is a new delivery pointing at the original one.
+type WebhookDelivery struct {
+	ID             uuid.UUID      `gorm:"primaryKey;type:uuid"`
+	EndpointID     uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_event,where:redelivery_of IS NULL;index:idx_webhook_deliveries_endpoint,priority:1"`
+	EventID        string         `gorm:"not null;size:100;uniqueIndex:idx_webhook_deliveries_event,where:redelivery_of IS NULL"`
+	EventType      string         `gorm:"not null;size:100"`
+	Payload        datatypes.JSON `gorm:"type:jsonb;not null"` // CloudEvent sent as the request body
+	Status         string         `gorm:"not null;size:20;index"`
+	Attempts       int            `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time      `gorm:"not null;index"`
+	LastStatusCode int
+	LastError      string `gorm:"size:500"`
+	LastResponse   string `gorm:"size:1000"` // Beginning of the last response body
+	LastDurationMs int64
+	RedeliveryOf   *uuid.UUID `gorm:"type:uuid"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"index:idx_webhook_deliveries_endpoint,priority:2"`
+	UpdatedAt      time.Time
+
+	Endpoint WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BeforeCreate hook to generate UUID
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	if d.Status == "" {
+		d.Status = WebhookDeliveryPending
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = time.Now()
+	}
+	return nil
+}
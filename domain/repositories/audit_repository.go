@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditFilter selects audit events for Search, newest first. Zero values mean "no filter".
+type AuditFilter struct {
+	EventType string // Exact type, or a prefix when it ends with "."
+	Outcome   string
+	ActorID   *uuid.UUID
+	SubjectID *uuid.UUID
+	// UserID matches events where the user is either the actor or the subject
+	UserID *uuid.UUID
+	From   *time.Time
+	To     *time.Time
+
+	// Keyset position: events strictly older than (BeforeCreatedAt, BeforeID)
+	BeforeCreatedAt *time.Time
+	BeforeID        *uuid.UUID
+	Limit           int
+}
+
+// AuditRepository is append-only: events can be written and read, never changed
+type AuditRepository interface {
+	// Create links the event to the chain head and stores it; appends are serialized
+	Create(ctx context.Context, event *models.AuditEvent) error
+	Search(ctx context.Context, filter *AuditFilter) ([]*models.AuditEvent, error)
+
+	// ListChain returns events in chain order with Sequence greater than afterSequence
+	ListChain(ctx context.Context, afterSequence int64, limit int) ([]*models.AuditEvent, error)
+	// GetChainHead returns the newest chained event, or nil, nil when the chain is empty
+	GetChainHead(ctx context.Context) (*models.AuditEvent, error)
+
+	CreateCheckpoint(ctx context.Context, checkpoint *models.AuditCheckpoint) error
+	// GetLatestCheckpoint returns nil, nil when no checkpoint has been written
+	GetLatestCheckpoint(ctx context.Context) (*models.AuditCheckpoint, error)
+	ListCheckpoints(ctx context.Context) ([]*models.AuditCheckpoint, error)
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterFilter selects dead-letter events for List, oldest first. Zero values mean "no filter".
+type DeadLetterFilter struct {
+	Topic       string
+	Source      string
+	AggregateID *uuid.UUID
+	Offset      int
+	Limit       int
+}
+
+type DeadLetterRepository interface {
+	// Create stores the event and removes the outbox event with the same ID, if any, in one transaction.
+	// Storing an event that is already dead-lettered replaces it.
+	Create(ctx context.Context, event *models.DeadLetterEvent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetterEvent, error)
+	// List returns one page of events matching the filter and the total number of matches
+	List(ctx context.Context, filter *DeadLetterFilter) ([]*models.DeadLetterEvent, int64, error)
+	// Requeue moves the event back into the outbox under its original ID, in one transaction.
+	// It is queued behind events of the same aggregate published since, so it is delivered out of order.
+	Requeue(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Count(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, session *models.ImpersonationSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ImpersonationSession, error)
+	ListByTarget(ctx context.Context, targetUserID uuid.UUID) ([]*models.ImpersonationSession, error)
+	// ListExpired returns sessions past their expiry that have not been closed yet
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.ImpersonationSession, error)
+	// End closes the session; it returns false if it was already closed
+	End(ctx context.Context, id uuid.UUID, at time.Time, endedBy *uuid.UUID, reason string) (bool, error)
+}
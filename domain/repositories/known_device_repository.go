@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type KnownDeviceRepository interface {
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.KnownDevice, error)
+	// Upsert records a sign-in, creating the device or updating when and where it was last seen
+	Upsert(ctx context.Context, device *models.KnownDevice) error
+
+	CreateAlert(ctx context.Context, alert *models.LoginAlert) error
+	GetAlertByTokenHash(ctx context.Context, tokenHash string) (*models.LoginAlert, error)
+	// MarkAlertUsed consumes the alert's link; it returns false if it was already used
+	MarkAlertUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptState is the recent failed sign-in history of one account or IP address
+type LoginAttemptState struct {
+	Failures      int64
+	LastFailureAt *time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginAttemptRepository tracks failed sign-ins per opaque key (hashed email or IP address)
+type LoginAttemptRepository interface {
+	GetState(ctx context.Context, key string) (*LoginAttemptState, error)
+	// RecordFailure counts a failure and returns the new total.
+	// The count is forgotten once window has passed without further failures.
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (int64, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Clear forgets failures and any lock for the key
+	Clear(ctx context.Context, key string) error
+}
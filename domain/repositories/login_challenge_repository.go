@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginChallenge is a sign-in held back until the user enters the one-time code sent to them
+type LoginChallenge struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Method    string    `json:"method"`    // Sign-in method that was challenged ("password" | "oauth")
+	CodeHash  string    `json:"code_hash"` // SHA-256 of the one-time code
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoginChallengeRepository stores pending step-up challenges until they expire
+type LoginChallengeRepository interface {
+	Create(ctx context.Context, id string, challenge *LoginChallenge) error
+	// Get returns nil when the challenge does not exist or has expired
+	Get(ctx context.Context, id string) (*LoginChallenge, error)
+	// RecordFailure counts a wrong code and returns the new total
+	RecordFailure(ctx context.Context, id string) (int64, error)
+	// Delete consumes the challenge; it returns false if it no longer existed
+	Delete(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository stores organizations, memberships and invitations. Writes that change
+// memberships take the outbox events describing them and store them in the same transaction.
+type OrganizationRepository interface {
+	// Organizations
+	Create(ctx context.Context, org *models.Organization, owner *models.OrganizationMember, events ...*models.OutboxEvent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Organization, error)
+	Update(ctx context.Context, org *models.Organization) error
+	Delete(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error
+
+	// Memberships (GetMember returns nil, nil when the user is not a member)
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationMember, error)
+	ListMembershipsByUser(ctx context.Context, userID uuid.UUID) ([]*models.OrganizationMember, error)
+	GetLastActiveMembership(ctx context.Context, userID uuid.UUID) (*models.OrganizationMember, error)
+	AddMember(ctx context.Context, member *models.OrganizationMember) error
+	UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string, events ...*models.OutboxEvent) error
+	TouchMember(ctx context.Context, orgID, userID uuid.UUID, at time.Time) error
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID, events ...*models.OutboxEvent) error
+	CountMembersWithRole(ctx context.Context, orgID uuid.UUID, role string) (int64, error)
+
+	// Invitations
+	CreateInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error
+	GetInvitationByID(ctx context.Context, id uuid.UUID) (*models.OrganizationInvitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.OrganizationInvitation, error)
+	ListPendingInvitations(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationInvitation, error)
+	UpdateInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error
+	AcceptInvitation(ctx context.Context, invitation *models.OrganizationInvitation, member *models.OrganizationMember, events ...*models.OutboxEvent) error
+}
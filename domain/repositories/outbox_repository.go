@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	// ClaimPending leases up to limit unpublished events that are due at now, hiding them from
+	// other relays until leaseUntil. Only the oldest pending event of each aggregate is eligible,
+	// so events of one aggregate are never published out of order.
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error
+	// MarkFailed records a failed attempt and schedules the next one
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	// DeletePublishedBefore removes events published before the given time and returns how many were removed
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+	// CountPending returns the number of events not yet published
+	CountPending(ctx context.Context) (int64, error)
+}
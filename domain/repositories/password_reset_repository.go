@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	// MarkUsed consumes the token; it returns false if it was already used
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	// InvalidateForUser consumes every outstanding token of the user
+	InvalidateForUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+}
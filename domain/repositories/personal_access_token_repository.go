@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *models.PersonalAccessToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PersonalAccessToken, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error)
+	CountActiveByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+type RoleRepository interface {
+	// Roles
+	Create(ctx context.Context, role *models.Role) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Role, error)
+	GetByName(ctx context.Context, name string) (*models.Role, error)
+	List(ctx context.Context) ([]*models.Role, error)
+	// Update saves the role and, when permissions is not nil, replaces its permissions in the same transaction
+	Update(ctx context.Context, role *models.Role, permissions []models.Permission) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListHolderIDs returns the users holding the role, by assignment or by the legacy users.role column
+	ListHolderIDs(ctx context.Context, role *models.Role) ([]uuid.UUID, error)
+
+	// Permissions
+	ListPermissions(ctx context.Context) ([]*models.Permission, error)
+	GetPermissionsByNames(ctx context.Context, names []string) ([]models.Permission, error)
+
+	// User assignments
+	AssignToUser(ctx context.Context, userRole *models.UserRole) error
+	RemoveFromUser(ctx context.Context, userID, roleID uuid.UUID) error
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.Role, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.UserSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.UserSession, error)
+	// ListActiveByUser returns sessions that are neither revoked nor expired, most recently used first
+	ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.UserSession, error)
+	// HasIPAddress reports whether any session of the user, including ended ones, was last used from ip
+	HasIPAddress(ctx context.Context, userID uuid.UUID, ip string) (bool, error)
+	// GetLatestByUser returns the most recently used session, or nil when the user has none
+	GetLatestByUser(ctx context.Context, userID uuid.UUID) (*models.UserSession, error)
+	// Touch records activity from the given address; rows seen after staleBefore are left alone
+	Touch(ctx context.Context, id uuid.UUID, at, staleBefore time.Time, ipAddress string) error
+	// Extend moves the expiry of an open session and records activity
+	Extend(ctx context.Context, id uuid.UUID, at, expiresAt time.Time) error
+	// Revoke closes the session; it returns false if it was already revoked
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time, reason string) (bool, error)
+	// RevokeAllForUser closes every open session of the user
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time, reason string) error
+}
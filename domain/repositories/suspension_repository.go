@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuspensionRepository interface {
+	// Create stores the suspension and the given outbox events in one transaction
+	Create(ctx context.Context, suspension *models.UserSuspension, events ...*models.OutboxEvent) error
+	// GetActiveByUser returns nil, nil when the user is not suspended at the given time
+	GetActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) (*models.UserSuspension, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSuspension, error)
+	// ListExpired returns unlifted suspensions whose end time has passed
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UserSuspension, error)
+	// Lift marks the suspension lifted and stores the events with it; it returns false, writing
+	// nothing, if the suspension was already lifted
+	Lift(ctx context.Context, id uuid.UUID, at time.Time, liftedBy *uuid.UUID, reason string, events ...*models.OutboxEvent) (bool, error)
+}
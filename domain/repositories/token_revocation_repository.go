@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocationRepository stores server-side revocation state for issued JWTs
+type TokenRevocationRepository interface {
+	// RevokeToken marks a single token (by jti) as revoked until it would have expired
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// RevokeSession marks every token of a session (by sid) as revoked until the session would have expired
+	RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+
+	// RevokeUserTokens revokes every token of a user issued at or before the given time. The cutoff
+	// is kept in milliseconds, the precision of the iat_ms claim.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, before time.Time) error
+	GetUserTokensRevokedBefore(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+
+	// RevokeMembershipTokens revokes the tokens of a user that carry orgID as the active organization
+	// and were issued before the given time, with the same precision as RevokeUserTokens
+	RevokeMembershipTokens(ctx context.Context, userID, orgID uuid.UUID, before time.Time) error
+	GetMembershipTokensRevokedBefore(ctx context.Context, userID, orgID uuid.UUID) (*time.Time, error)
+}
@@ -3,16 +3,54 @@ package repositories
 import (
 	"context"
 	"gofiber-template/domain/models"
+	"time"
 	"github.com/google/uuid"
 )
 
+// Sort keys accepted by UserFilter.SortBy
+const (
+	UserSortCreatedAt   = "created_at"
+	UserSortUpdatedAt   = "updated_at"
+	UserSortLastLoginAt = "last_login_at"
+	UserSortEmail       = "email"
+	UserSortUsername    = "username"
+)
+
+// UserFilter selects and orders users for Search. Zero values mean "no filter".
+type UserFilter struct {
+	Search        string // Case-insensitive match on email, username or display name
+	Role          string
+	IsActive      *bool
+	Provider      string // OAuth provider, or "local" for password accounts
+	EmailVerified *bool
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	LastLoginFrom *time.Time
+	LastLoginTo   *time.Time
+
+	SortBy   string // One of the UserSort* keys
+	SortDesc bool
+
+	// Keyset pagination: return rows after (AfterValue, AfterID) in sort order.
+	// When AfterID is nil, Offset is used instead.
+	AfterValue string
+	AfterID    *uuid.UUID
+	Offset     int
+	Limit      int
+}
+
+// Writes accept outbox events, which are stored in the same transaction as the user change
 type UserRepository interface {
-	Create(ctx context.Context, user *models.User) error
+	Create(ctx context.Context, user *models.User, events ...*models.OutboxEvent) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
-	Update(ctx context.Context, id uuid.UUID, user *models.User) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Update(ctx context.Context, id uuid.UUID, user *models.User, events ...*models.OutboxEvent) error
+	// UpdateFields writes the given columns, including zero values that Update skips
+	UpdateFields(ctx context.Context, id uuid.UUID, fields map[string]interface{}, events ...*models.OutboxEvent) error
+	Delete(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error
 	List(ctx context.Context, offset, limit int) ([]*models.User, error)
+	// Search returns one page of users matching the filter and the total number of matches
+	Search(ctx context.Context, filter *UserFilter) ([]*models.User, int64, error)
 	Count(ctx context.Context) (int64, error)
 }
\ No newline at end of file
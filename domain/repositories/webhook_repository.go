@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryFilter selects deliveries of one endpoint for ListDeliveries, newest first.
+// Zero values mean "no filter".
+type WebhookDeliveryFilter struct {
+	EndpointID uuid.UUID
+	Status     string
+	EventType  string
+	Offset     int
+	Limit      int
+}
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error)
+	ListActiveEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id uuid.UUID, fields map[string]interface{}) error
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// RecordFailure increments the endpoint's consecutive failures and returns the new count
+	RecordFailure(ctx context.Context, id uuid.UUID) (int, error)
+	RecordSuccess(ctx context.Context, id uuid.UUID) error
+
+	// CreateDeliveries stores new deliveries, skipping events already delivered to the same
+	// endpoint, so enqueuing an event again is harmless
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	CreateRedelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error)
+	// ListDeliveries returns one page of deliveries matching the filter and the total number of matches
+	ListDeliveries(ctx context.Context, filter *WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error)
+	// ClaimDueDeliveries returns pending deliveries of active endpoints that are due and leases
+	// them until leaseUntil so other dispatchers skip them
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// UpdateDelivery stores the outcome of an attempt
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// DeleteDeliveriesBefore removes finished deliveries created before the given time
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
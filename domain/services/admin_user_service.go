@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// AdminUserService lets administrators manage other users' accounts.
+// actorID is the administrator performing the operation; admins cannot
+// deactivate, delete or change the role of their own account.
+type AdminUserService interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	UpdateUser(ctx context.Context, actorID, userID uuid.UUID, req *dto.AdminUpdateUserRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error
+	ActivateUser(ctx context.Context, actorID, userID uuid.UUID) (*models.User, error)
+	DeactivateUser(ctx context.Context, actorID, userID uuid.UUID, reason string) (*models.User, error)
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*models.User, error)
+	// ForcePasswordReset clears the password, ends all sessions and emails a reset link
+	ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) error
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// AuditService writes and queries the security audit log
+type AuditService interface {
+	// Record persists an event. Request ID, client IP, user agent and the acting user are taken
+	// from the context when not set on the event. Failures are logged, never returned, so
+	// auditing cannot break the operation being audited.
+	Record(ctx context.Context, event *models.AuditEvent)
+
+	// Search returns one page of events, newest first, and the cursor of the next page
+	Search(ctx context.Context, query *dto.AuditEventQuery) ([]*models.AuditEvent, string, error)
+	// RecentActivity returns the latest events performed by or on the user
+	RecentActivity(ctx context.Context, userID uuid.UUID, limit int) ([]*models.AuditEvent, error)
+
+	// WriteCheckpoint signs the current chain head. It returns nil, nil when there is nothing
+	// new since the last checkpoint or no signing key is configured.
+	WriteCheckpoint(ctx context.Context) (*models.AuditCheckpoint, error)
+}
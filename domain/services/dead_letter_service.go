@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterService keeps events that could not be delivered so administrators can
+// inspect them and retry or discard them
+type DeadLetterService interface {
+	// Add stores an undeliverable event, removing it from the outbox, and raises the
+	// dead-letter alert (metric and error log with the queue size)
+	Add(ctx context.Context, event *models.DeadLetterEvent) error
+
+	List(ctx context.Context, query *dto.DeadLetterQuery) ([]*models.DeadLetterEvent, *dto.PaginationMeta, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.DeadLetterEvent, error)
+	// Retry moves the event back into the outbox; the relay publishes it with its original ID
+	Retry(ctx context.Context, actorID, id uuid.UUID) error
+	// Discard drops the event without delivering it
+	Discard(ctx context.Context, actorID, id uuid.UUID) error
+}
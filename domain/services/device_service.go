@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+)
+
+// DeviceService remembers the devices and countries users sign in from and alerts them
+// about unfamiliar sign-ins
+type DeviceService interface {
+	// RecordLogin fingerprints the device in the context. Sign-ins from a new device or country
+	// of a user with known devices send an email and publish user.login.new_device.
+	// Failures are logged only; they never block the sign-in.
+	RecordLogin(ctx context.Context, user *models.User, method string)
+	// ConsumeAlert redeems the token of a "this wasn't me" link
+	ConsumeAlert(ctx context.Context, token string) (*models.LoginAlert, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import "context"
+
+// EmailSender delivers transactional emails (invitations, security notifications)
+type EmailSender interface {
+	Send(ctx context.Context, message *EmailMessage) error
+}
+
+// EmailMessage is a plain-text transactional email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -1,48 +1,84 @@
 package services
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // EventPublisher defines the interface for publishing events to message brokers
 // This abstraction allows switching between different event bus implementations
 // (NATS, Kafka, RabbitMQ, etc.) without changing business logic
+//
+// msgID identifies the message for broker-side deduplication: publishing the same msgID
+// again (a retry) within the duplicate window stores the message only once, so it must be
+// derived from the event rather than generated per attempt.
 type EventPublisher interface {
-	// Publish sends an event synchronously to the specified topic
+	// Publish sends an event to the specified topic and waits for the broker to acknowledge it
 	// Returns error if publishing fails
-	Publish(ctx context.Context, topic string, payload interface{}) error
+	Publish(ctx context.Context, topic, msgID string, payload interface{}) error
 
 	// PublishAsync sends an event asynchronously (fire-and-forget)
 	// Errors are logged but not returned
-	PublishAsync(topic string, payload interface{})
+	PublishAsync(topic, msgID string, payload interface{})
 
 	// Close gracefully shuts down the publisher and closes connections
 	Close() error
 }
 
-// Event represents a generic event structure
-type Event struct {
-	ID        string                 `json:"id"`        // Unique event ID
-	Type      string                 `json:"type"`      // Event type (e.g., "user.created")
-	Timestamp int64                  `json:"timestamp"` // Unix timestamp
-	Data      map[string]interface{} `json:"data"`      // Event payload
-}
+// Events are published as CloudEvents 1.0 (see pkg/cloudevents). The types below are the
+// event data; request ID, time and source travel in the envelope.
 
-// UserEventData represents minimal identity event data
+// UserEventData is the data of every user.* event: the minimal identity fields plus
+// optional fields describing the change, set depending on the event type.
 // Auth Service sends only essential identity information.
 // Downstream services (Social, Profile) are responsible for enriching user data.
 type UserEventData struct {
-	// Minimal Identity Data (Primary Key + Identifiers)
 	ID       string `json:"id"`       // User ID (Primary Key)
 	Email    string `json:"email"`    // Email address (identifier)
 	Username string `json:"username"` // Username (identifier)
-	Action   string `json:"action"`   // "created" | "updated" | "deleted"
+	Action   string `json:"action"`   // Last segment of the event type, e.g. "created"
 
-	// Observability Metadata
-	RequestID   string `json:"request_id"`           // Correlation ID for distributed tracing
-	Timestamp   string `json:"timestamp"`            // ISO 8601 timestamp
-	Sequence    uint64 `json:"sequence,omitempty"`   // NATS JetStream sequence number
-	ServiceName string `json:"service_name"`         // Source service (e.g., "gofiber-auth")
+	Method       string     `json:"method,omitempty"`        // logged_in: "password" | "oauth"
+	Provider     string     `json:"provider,omitempty"`      // logged_in via OAuth, provider_linked, provider_unlinked
+	SessionID    string     `json:"session_id,omitempty"`    // session_revoked of a single session
+	Role         string     `json:"role,omitempty"`          // role_changed: new primary role
+	PreviousRole string     `json:"previous_role,omitempty"` // role_changed
+	Reason       string     `json:"reason,omitempty"`        // password_changed, session_revoked
+	EndsAt       *time.Time `json:"ends_at,omitempty"`       // suspended: end of a temporary suspension
+	ActorID      string     `json:"actor_id,omitempty"`      // Administrator who made the change
 }
 
+// Reasons of user.password_changed
+const (
+	PasswordChangedByUser = "changed" // The user changed it with the current password
+	PasswordReset         = "reset"   // Set through a password reset link
+	PasswordCleared       = "cleared" // Removed by an administrator or after an unrecognized sign-in; a reset email was sent
+)
+
 // Note: Fields removed from events (managed by downstream services):
 // - displayName, avatar, bio → Managed by Social/Profile Service
 // - role, isActive, permissions → Internal to Auth Service, not needed by downstream
+
+// MembershipEventData is published when organization membership changes.
+// Topics: membership.added | membership.removed | membership.role_changed
+type MembershipEventData struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`                    // Role after the change ("" when removed)
+	PreviousRole   string `json:"previous_role,omitempty"` // Set for role changes and removals
+	Action         string `json:"action"`                  // "added" | "removed" | "role_changed"
+	ActorID        string `json:"actor_id,omitempty"`      // User who performed the change
+}
+
+// LoginAlertEventData is published when a user signs in from a new device or country.
+// Topic: login.new_device (event type user.login.new_device)
+type LoginAlertEventData struct {
+	UserID     string `json:"user_id"`
+	DeviceName string `json:"device_name"`
+	IPAddress  string `json:"ip_address"`
+	Country    string `json:"country,omitempty"`
+	Location   string `json:"location,omitempty"`
+	NewDevice  bool   `json:"new_device"`
+	NewCountry bool   `json:"new_country"`
+	Method     string `json:"method"` // "password" | "oauth"
+}
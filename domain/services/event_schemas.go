@@ -0,0 +1,93 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventSchema describes one event type and the data schema versions it is published in.
+// Every version is published on its own subject (<subject>.v<N>.<topic>) so consumers can
+// pin a version during migrations. To change the data of a type incompatibly, add the new
+// struct as Data with Version+1 and move the old one to Previous with a Downgrade.
+type EventSchema struct {
+	Type     string      // CloudEvents type
+	Topic    string      // Subject suffix below the NATS subject
+	Version  int         // Current data schema version
+	Data     interface{} // Zero value of the current data struct
+	Previous []PreviousEventSchema
+}
+
+// PreviousEventSchema is an older data version that is still published
+type PreviousEventSchema struct {
+	Version   int
+	Data      interface{}
+	Downgrade func(current interface{}) interface{} // Converts current data to this version
+}
+
+// User event versions continue the numbering of the earlier flat payloads (V1 full profile, V2 minimal identity).
+// V1 is retired: it carried profile fields this service no longer holds, so it cannot be produced and
+// has no Previous entry; v1.<topic> subjects are never published for user.* types. The flat V2 payload
+// stays available on the unversioned subject while legacy payloads are enabled.
+// All user.* types share UserEventData, so types added later start at the same version.
+var eventSchemas = []EventSchema{
+	{Type: "user.created", Topic: "created", Version: 2, Data: UserEventData{}},
+	{Type: "user.updated", Topic: "updated", Version: 2, Data: UserEventData{}},
+	{Type: "user.deleted", Topic: "deleted", Version: 2, Data: UserEventData{}},
+	{Type: "user.activated", Topic: "activated", Version: 2, Data: UserEventData{}},
+	{Type: "user.deactivated", Topic: "deactivated", Version: 2, Data: UserEventData{}},
+	{Type: "user.role_changed", Topic: "role_changed", Version: 2, Data: UserEventData{}},
+	{Type: "user.suspended", Topic: "suspended", Version: 2, Data: UserEventData{}},
+	{Type: "user.reinstated", Topic: "reinstated", Version: 2, Data: UserEventData{}},
+	{Type: "user.logged_in", Topic: "logged_in", Version: 2, Data: UserEventData{}},
+	{Type: "user.password_changed", Topic: "password_changed", Version: 2, Data: UserEventData{}},
+	{Type: "user.email_verified", Topic: "email_verified", Version: 2, Data: UserEventData{}},
+	{Type: "user.provider_linked", Topic: "provider_linked", Version: 2, Data: UserEventData{}},
+	{Type: "user.provider_unlinked", Topic: "provider_unlinked", Version: 2, Data: UserEventData{}},
+	{Type: "user.session_revoked", Topic: "session_revoked", Version: 2, Data: UserEventData{}},
+	{Type: "membership.added", Topic: "membership.added", Version: 1, Data: MembershipEventData{}},
+	{Type: "membership.removed", Topic: "membership.removed", Version: 1, Data: MembershipEventData{}},
+	{Type: "membership.role_changed", Topic: "membership.role_changed", Version: 1, Data: MembershipEventData{}},
+	{Type: "user.login.new_device", Topic: "login.new_device", Version: 1, Data: LoginAlertEventData{}},
+}
+
+// EventSchemas returns every published event type, sorted by type
+func EventSchemas() []EventSchema {
+	schemas := make([]EventSchema, len(eventSchemas))
+	copy(schemas, eventSchemas)
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Type < schemas[j].Type })
+	return schemas
+}
+
+// LookupEventSchema returns the schema of an event type
+func LookupEventSchema(eventType string) (EventSchema, bool) {
+	for _, schema := range eventSchemas {
+		if schema.Type == eventType {
+			return schema, true
+		}
+	}
+	return EventSchema{}, false
+}
+
+// EventSchemaURL returns the dataschema URI of a type's data version below baseURL
+func EventSchemaURL(baseURL, eventType string, version int) string {
+	return fmt.Sprintf("%s/%s/v%d", strings.TrimRight(baseURL, "/"), eventType, version)
+}
+
+// VersionedTopic returns the topic a data version is published on
+func VersionedTopic(version int, topic string) string {
+	return fmt.Sprintf("v%d.%s", version, topic)
+}
+
+// DataForVersion returns the zero value of the data struct of the given version
+func (s EventSchema) DataForVersion(version int) (interface{}, bool) {
+	if version == s.Version {
+		return s.Data, true
+	}
+	for _, previous := range s.Previous {
+		if previous.Version == version {
+			return previous.Data, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// ImpersonationService lets administrators act as another user for support purposes.
+// Every session is recorded in impersonation_sessions from start to end.
+type ImpersonationService interface {
+	// Start records the client IP and user agent carried by ctx
+	Start(ctx context.Context, actorID, targetID uuid.UUID, req *dto.StartImpersonationRequest) (*dto.ImpersonationResponse, error)
+	// End closes an open session; endedBy is the impersonating administrator or another administrator
+	End(ctx context.Context, sessionID, endedBy uuid.UUID) error
+	ListForUser(ctx context.Context, targetID uuid.UUID) ([]*models.ImpersonationSession, error)
+
+	// CloseExpired records the end of sessions whose token has expired and returns how many were closed
+	CloseExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginThrottledError is returned while an account or IP address must wait before signing in again.
+// The message is the same whether or not the account exists.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many failed login attempts, please try again later"
+}
+
+// LockoutService applies progressive delays and temporary lockouts after failed sign-ins.
+// Attempts are tracked per email address, existing or not, and per client IP taken from the context.
+type LockoutService interface {
+	// CheckLogin returns a *LoginThrottledError when the attempt must be rejected without checking the password
+	CheckLogin(ctx context.Context, email string) error
+	// RecordFailure counts a failed attempt. user is nil when no account matches email.
+	RecordFailure(ctx context.Context, email string, user *models.User)
+	RecordSuccess(ctx context.Context, email string)
+
+	GetStatus(ctx context.Context, userID uuid.UUID) (*dto.LockoutStatusResponse, error)
+	Unlock(ctx context.Context, actorID, userID uuid.UUID) error
+}
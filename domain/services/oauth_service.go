@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
 )
 
 type OAuthService interface {
@@ -17,4 +19,7 @@ type OAuthService interface {
 	// LINE OAuth
 	GetLINEAuthURL(state string) string
 	HandleLINECallback(ctx context.Context, code string) (*models.User, string, bool, error)
+
+	// Linked providers
+	UnlinkProvider(ctx context.Context, userID uuid.UUID, provider string) error
 }
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationService manages team workspaces, memberships and invitations.
+// actorID is always the authenticated user performing the operation.
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, actorID uuid.UUID, req *dto.CreateOrganizationRequest) (*models.Organization, error)
+	ListMyOrganizations(ctx context.Context, actorID uuid.UUID) ([]*models.OrganizationMember, error)
+	GetOrganization(ctx context.Context, actorID, orgID uuid.UUID) (*models.OrganizationMember, error)
+	UpdateOrganization(ctx context.Context, actorID, orgID uuid.UUID, req *dto.UpdateOrganizationRequest) (*models.Organization, error)
+	DeleteOrganization(ctx context.Context, actorID, orgID uuid.UUID) error
+
+	ListMembers(ctx context.Context, actorID, orgID uuid.UUID) ([]*models.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, actorID, orgID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, actorID, orgID, userID uuid.UUID) error
+
+	CreateInvitation(ctx context.Context, actorID, orgID uuid.UUID, req *dto.CreateInvitationRequest) (*models.OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, actorID, orgID uuid.UUID) ([]*models.OrganizationInvitation, error)
+	RevokeInvitation(ctx context.Context, actorID, orgID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, actorID uuid.UUID, token string) (*models.OrganizationMember, error)
+
+	// SwitchOrganization makes orgID the active organization and issues tokens carrying it
+	SwitchOrganization(ctx context.Context, actorID, orgID uuid.UUID) (string, *models.OrganizationMember, error)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessTokenService manages user-created API tokens.
+// A token grants the intersection of its scopes and the owner's current permissions.
+type PersonalAccessTokenService interface {
+	// CreateToken returns the stored token and the plaintext value, which is not retrievable later
+	CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreatePersonalAccessTokenRequest) (*models.PersonalAccessToken, string, error)
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error
+	// RevokePlaintext revokes a token presented by value; unknown tokens are ignored (RFC 7009)
+	RevokePlaintext(ctx context.Context, token string) error
+
+	Authenticate(ctx context.Context, token string) (*utils.UserContext, error)
+	Introspect(ctx context.Context, token string) (*dto.IntrospectionResponse, error)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// RBACService manages roles, permissions and role assignments
+type RBACService interface {
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	GetRole(ctx context.Context, id uuid.UUID) (*models.Role, error)
+	// CreateRole and UpdateRole reject permissions that actorID does not hold
+	CreateRole(ctx context.Context, req *dto.CreateRoleRequest, actorID uuid.UUID) (*models.Role, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req *dto.UpdateRoleRequest, actorID uuid.UUID) (*models.Role, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	ListPermissions(ctx context.Context) ([]*models.Permission, error)
+
+	GetUserRoles(ctx context.Context, userID uuid.UUID) (*dto.UserRolesResponse, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, roleName string, assignedBy uuid.UUID) error
+	RemoveRole(ctx context.Context, userID, roleID uuid.UUID, removedBy uuid.UUID) error
+
+	// ResolveAuthorization returns the effective role names and permissions of a user
+	ResolveAuthorization(ctx context.Context, user *models.User) ([]string, []string, error)
+	// CheckGrantable rejects a role that grants permissions the granting user does not hold
+	CheckGrantable(ctx context.Context, grantorID uuid.UUID, role *models.Role) error
+	// CheckManageable rejects acting on a user who holds permissions the acting user does not hold
+	CheckManageable(ctx context.Context, actorID uuid.UUID, target *models.User) error
+}
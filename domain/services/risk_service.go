@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/models"
+	"time"
+)
+
+// ErrLoginDenied is returned when a sign-in with valid credentials is judged too risky to allow
+var ErrLoginDenied = errors.New("sign-in blocked for security reasons, please contact support")
+
+// StepUpRequiredError is returned when a sign-in must be confirmed with a one-time code
+// before tokens are issued. The code has already been sent.
+type StepUpRequiredError struct {
+	ChallengeID string
+	Method      string // How the code was delivered: "email_otp"
+	ExpiresIn   time.Duration
+}
+
+func (e *StepUpRequiredError) Error() string {
+	return "additional verification required"
+}
+
+// RiskService scores sign-ins whose credentials are valid and records every decision for audit
+type RiskService interface {
+	// CheckLogin returns nil to allow the sign-in, a *StepUpRequiredError or ErrLoginDenied.
+	// method is the sign-in method ("password" | "oauth").
+	CheckLogin(ctx context.Context, user *models.User, method string) error
+	// VerifyChallenge consumes a step-up challenge and returns the user and sign-in method it confirms
+	VerifyChallenge(ctx context.Context, challengeID, code string) (*models.User, string, error)
+}
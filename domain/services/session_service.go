@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionService records sign-ins per device and revokes them
+type SessionService interface {
+	// Start records a new sign-in by the client in the context
+	Start(ctx context.Context, userID uuid.UUID, expiresAt time.Time) (*models.UserSession, error)
+	// Resume checks in the database that the session is still open and extends it to expiresAt.
+	// Used when switching organization, so it fails closed.
+	Resume(ctx context.Context, userID, sessionID uuid.UUID, expiresAt time.Time) error
+	// IsRevoked checks the fast revocation store; lookup failures return utils.ErrRevocationUnavailable
+	IsRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+	// Touch records activity, writing at most once per interval per session
+	Touch(ctx context.Context, sessionID uuid.UUID)
+
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error)
+	// Revoke signs the user out of one session, invalidating all of its tokens
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID, reason string) error
+	// RevokeAllForUser closes every session record; tokens are revoked user-wide by the caller
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
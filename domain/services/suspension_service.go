@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// SuspensionService manages temporary suspensions and indefinite bans
+type SuspensionService interface {
+	Suspend(ctx context.Context, actorID, userID uuid.UUID, req *dto.SuspendUserRequest) (*models.UserSuspension, error)
+	Lift(ctx context.Context, actorID, userID uuid.UUID, reason string) error
+	ListForUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSuspension, error)
+
+	// CheckSignIn returns an error describing the suspension when the user may not sign in
+	CheckSignIn(ctx context.Context, userID uuid.UUID) error
+
+	// LiftExpired reinstates users whose suspension has ended and returns how many were lifted
+	LiftExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/utils"
+	"time"
+)
+
+// TokenService issues, validates and revokes JWTs.
+// It is the single place that knows about server-side revocation state.
+type TokenService interface {
+	// GenerateAccessToken starts a session and issues a token for the user's last active organization (if any)
+	GenerateAccessToken(ctx context.Context, user *models.User) (string, error)
+	// GenerateAccessTokenForMembership issues a token within the caller's session with the given organization as the active org
+	GenerateAccessTokenForMembership(ctx context.Context, user *models.User, membership *models.OrganizationMember) (string, error)
+	// GenerateImpersonationToken issues a non-refreshable access token for target carrying an act claim for actor.
+	// sessionID becomes the token's jti.
+	GenerateImpersonationToken(ctx context.Context, actor, target *models.User, sessionID uuid.UUID, expiresAt time.Time) (string, error)
+
+	// Authenticate validates an access token including revocation state
+	Authenticate(ctx context.Context, token string) (*utils.UserContext, error)
+
+	// Introspect implements RFC 7662 semantics (inactive tokens are not an error)
+	Introspect(ctx context.Context, token, tokenTypeHint string) (*dto.IntrospectionResponse, error)
+	// Revoke implements RFC 7009 semantics (unknown tokens are not an error)
+	Revoke(ctx context.Context, token, tokenTypeHint string) error
+	RevokeAllForUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -7,13 +7,30 @@ import (
 	"github.com/google/uuid"
 )
 
+// InvalidUserQueryError is returned by ListUsers when the sort key, a filter or the cursor cannot be used
+type InvalidUserQueryError struct {
+	Reason string
+}
+
+func (e *InvalidUserQueryError) Error() string {
+	return e.Reason
+}
+
 type UserService interface {
 	Register(ctx context.Context, req *dto.CreateUserRequest) (*models.User, error)
 	Login(ctx context.Context, req *dto.LoginRequest) (string, *models.User, error)
+	// VerifyLogin completes a sign-in held back by a *StepUpRequiredError
+	VerifyLogin(ctx context.Context, req *dto.VerifyLoginRequest) (string, *models.User, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateUserRequest) (*models.User, error)
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
-	ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int64, error)
-	GenerateJWT(user *models.User) (string, error)
+	ListUsers(ctx context.Context, query *dto.UserListQuery) ([]*models.User, *dto.PaginationMeta, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, req *dto.ChangePasswordRequest) error
+	// ReportUnrecognizedLogin handles "this wasn't me": it signs the user out everywhere,
+	// blocks the current password and emails a reset link
+	ReportUnrecognizedLogin(ctx context.Context, token string) error
+	GenerateJWT(ctx context.Context, user *models.User) (string, error)
 	ValidateJWT(token string) (*models.User, error)
 }
\ No newline at end of file
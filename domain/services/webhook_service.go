@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/cloudevents"
+
+	"github.com/google/uuid"
+)
+
+// WebhookService manages webhook endpoints and queues events for them. Deliveries are
+// sent by the webhook dispatcher, signed with the endpoint's secret.
+type WebhookService interface {
+	// CreateEndpoint registers an endpoint; a secret is generated when the request has none
+	CreateEndpoint(ctx context.Context, actorID uuid.UUID, req *dto.CreateWebhookRequest) (*models.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, actorID, id uuid.UUID, req *dto.UpdateWebhookRequest) (*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, actorID, id uuid.UUID) error
+
+	ListDeliveries(ctx context.Context, endpointID uuid.UUID, query *dto.WebhookDeliveryQuery) ([]*models.WebhookDelivery, *dto.PaginationMeta, error)
+	GetDelivery(ctx context.Context, endpointID, id uuid.UUID) (*models.WebhookDelivery, error)
+	// Redeliver queues the payload of a delivery again as a new delivery
+	Redeliver(ctx context.Context, actorID, endpointID, id uuid.UUID) (*models.WebhookDelivery, error)
+
+	// Enqueue queues the event for every active endpoint subscribed to its type. Enqueuing the
+	// same event again does not create duplicate deliveries.
+	Enqueue(ctx context.Context, event *cloudevents.Event) error
+}
@@ -0,0 +1,67 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/logger"
+)
+
+// SMTPSender implements the EmailSender interface using an SMTP relay
+type SMTPSender struct {
+	config *config.SMTPConfig
+}
+
+// NewEmailSender returns an SMTP sender, or a log-only sender when SMTP is not configured
+func NewEmailSender(cfg *config.SMTPConfig) services.EmailSender {
+	if cfg.Host == "" {
+		return &LogSender{}
+	}
+	return &SMTPSender{config: cfg}
+}
+
+// Send delivers the message through the configured SMTP server
+func (s *SMTPSender) Send(ctx context.Context, message *services.EmailMessage) error {
+	addr := fmt.Sprintf("%s:%s", s.config.Host, s.config.Port)
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	if err := smtp.SendMail(addr, auth, s.config.From, []string{message.To}, buildMessage(s.config.From, message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage renders RFC 5322 headers and a plain-text body
+func buildMessage(from string, message *services.EmailMessage) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + message.To + "\r\n")
+	b.WriteString("Subject: " + message.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+	return []byte(b.String())
+}
+
+// LogSender logs emails instead of sending them (development fallback)
+type LogSender struct{}
+
+// Send writes the message to the structured log
+func (s *LogSender) Send(ctx context.Context, message *services.EmailMessage) error {
+	logger.GetLogger().Info("Email not sent (SMTP not configured)", map[string]interface{}{
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	})
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/metrics"
 )
 
 // NATSPublisher implements the EventPublisher interface using NATS JetStream
@@ -41,8 +42,11 @@ func NewNATSPublisher(cfg *config.NATSConfig) (*NATSPublisher, error) {
 
 	var js nats.JetStreamContext
 	if cfg.EnableJetStream {
-		// Create JetStream context
-		js, err = nc.JetStream()
+		// Create JetStream context; publishes are async with a bounded number awaiting their ack
+		js, err = nc.JetStream(
+			nats.PublishAsyncMaxPending(cfg.MaxPendingAcks),
+			nats.PublishAsyncTimeout(cfg.AckTimeout),
+		)
 		if err != nil {
 			nc.Close()
 			return nil, fmt.Errorf("failed to create JetStream context: %w", err)
@@ -64,86 +68,139 @@ func NewNATSPublisher(cfg *config.NATSConfig) (*NATSPublisher, error) {
 	}, nil
 }
 
-// createOrUpdateStream creates or updates the JetStream stream
-func createOrUpdateStream(js nats.JetStreamContext, cfg *config.NATSConfig) error {
-	streamConfig := &nats.StreamConfig{
-		Name:        cfg.StreamName,
-		Subjects:    []string{cfg.Subject + ".*"}, // Match all subtopics
-		Storage:     nats.FileStorage,              // Persistent storage
-		Retention:   nats.WorkQueuePolicy,          // Messages deleted after ack
-		MaxAge:      7 * 24 * time.Hour,            // Keep messages for 7 days max
-		Duplicates:  5 * time.Minute,               // Duplicate detection window
-		Replicas:    1,                             // Single replica (change for HA)
-		Description: "User events stream for Auth Service",
-	}
+// Publish sends an event to NATS and waits for JetStream to acknowledge it.
+// msgID is sent as Nats-Msg-Id, so a retry within the stream's duplicate window is stored once.
+func (n *NATSPublisher) Publish(ctx context.Context, topic, msgID string, payload interface{}) error {
+	start := time.Now()
 
-	// Try to get existing stream
-	stream, err := js.StreamInfo(cfg.StreamName)
+	msg, err := n.message(topic, msgID, payload)
 	if err != nil {
-		// Stream doesn't exist, create it
-		_, err = js.AddStream(streamConfig)
-		if err != nil {
-			return fmt.Errorf("failed to create stream: %w", err)
-		}
-		log.Printf("✅ Created NATS stream: %s", cfg.StreamName)
-	} else {
-		// Stream exists, update it
-		_, err = js.UpdateStream(streamConfig)
+		return err
+	}
+
+	// Publish without JetStream (core NATS, no acknowledgment)
+	if n.js == nil {
+		err := n.conn.PublishMsg(msg)
+		n.record(topic, start, nil, err)
 		if err != nil {
-			return fmt.Errorf("failed to update stream: %w", err)
+			log.Printf("❌ Failed to publish to NATS: %v", err)
+			return fmt.Errorf("failed to publish: %w", err)
 		}
-		log.Printf("✅ Updated NATS stream: %s (msgs: %d)", cfg.StreamName, stream.State.Msgs)
+		return nil
 	}
 
-	return nil
+	future, err := n.js.PublishMsgAsync(msg)
+	if err != nil {
+		n.record(topic, start, nil, err)
+		log.Printf("❌ Failed to publish to NATS JetStream: %v", err)
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+
+	select {
+	case pubAck := <-future.Ok():
+		n.record(topic, start, pubAck, nil)
+		return nil
+	case err := <-future.Err():
+		n.record(topic, start, nil, err)
+		log.Printf("❌ Failed to publish to NATS JetStream: %v", err)
+		return fmt.Errorf("failed to publish: %w", err)
+	case <-ctx.Done():
+		// The ack may still arrive; a retry with the same msgID is deduplicated by the stream
+		n.record(topic, start, nil, ctx.Err())
+		return fmt.Errorf("failed to publish: %w", ctx.Err())
+	}
 }
 
-// Publish sends an event synchronously to NATS
-func (n *NATSPublisher) Publish(ctx context.Context, topic string, payload interface{}) error {
-	// Marshal payload to JSON
-	data, err := json.Marshal(payload)
+// PublishAsync sends an event without waiting for its acknowledgment (fire-and-forget).
+// The ack is awaited in the background and only logged and counted.
+func (n *NATSPublisher) PublishAsync(topic, msgID string, payload interface{}) {
+	start := time.Now()
+
+	msg, err := n.message(topic, msgID, payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
+		log.Printf("❌ Async publish failed: %v", err)
+		return
 	}
 
-	// Use full subject path
-	subject := fmt.Sprintf("%s.%s", n.config.Subject, topic)
-
-	// Publish with JetStream if enabled
-	if n.js != nil {
-		// Publish with acknowledgment
-		pubAck, err := n.js.Publish(subject, data)
+	if n.js == nil {
+		err := n.conn.PublishMsg(msg)
+		n.record(topic, start, nil, err)
 		if err != nil {
-			log.Printf("❌ Failed to publish to NATS JetStream: %v", err)
-			return fmt.Errorf("failed to publish: %w", err)
-		}
-		log.Printf("✅ Published to %s (stream: %s, seq: %d)", subject, pubAck.Stream, pubAck.Sequence)
-	} else {
-		// Publish without JetStream (core NATS)
-		err := n.conn.Publish(subject, data)
-		if err != nil {
-			log.Printf("❌ Failed to publish to NATS: %v", err)
-			return fmt.Errorf("failed to publish: %w", err)
+			log.Printf("❌ Async publish failed: %v", err)
 		}
-		log.Printf("✅ Published to %s", subject)
+		return
 	}
 
-	return nil
-}
+	future, err := n.js.PublishMsgAsync(msg)
+	if err != nil {
+		n.record(topic, start, nil, err)
+		log.Printf("❌ Async publish failed: %v", err)
+		return
+	}
 
-// PublishAsync sends an event asynchronously (fire-and-forget)
-func (n *NATSPublisher) PublishAsync(topic string, payload interface{}) {
+	// The ack timeout guarantees that one of the channels fires
 	go func() {
-		ctx := context.Background()
-		if err := n.Publish(ctx, topic, payload); err != nil {
+		select {
+		case pubAck := <-future.Ok():
+			n.record(topic, start, pubAck, nil)
+		case err := <-future.Err():
+			n.record(topic, start, nil, err)
 			log.Printf("❌ Async publish failed: %v", err)
 		}
 	}()
 }
 
+// message builds the NATS message of an event on <subject>.<topic>
+func (n *NATSPublisher) message(topic, msgID string, payload interface{}) (*nats.Msg, error) {
+	// Marshal payload to JSON
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	// Use full subject path
+	msg := nats.NewMsg(fmt.Sprintf("%s.%s", n.config.Subject, topic))
+	msg.Data = data
+	if msgID != "" {
+		msg.Header.Set(nats.MsgIdHdr, msgID)
+	}
+	return msg, nil
+}
+
+// record reports the outcome and latency of a publish. pubAck is nil without JetStream.
+func (n *NATSPublisher) record(topic string, start time.Time, pubAck *nats.PubAck, err error) {
+	status := "success"
+	switch {
+	case err != nil:
+		status = "failure"
+	case pubAck != nil && pubAck.Duplicate:
+		// Already stored by an earlier attempt
+		status = "duplicate"
+	}
+
+	metrics.EventPublishDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	metrics.EventsPublishedTotal.WithLabelValues(topic, status).Inc()
+
+	switch {
+	case pubAck != nil:
+		log.Printf("✅ Published to %s.%s (stream: %s, seq: %d, duplicate: %t)", n.config.Subject, topic, pubAck.Stream, pubAck.Sequence, pubAck.Duplicate)
+	case err == nil:
+		log.Printf("✅ Published to %s.%s", n.config.Subject, topic)
+	}
+}
+
 // Close gracefully shuts down the NATS connection
 func (n *NATSPublisher) Close() error {
 	if n.conn != nil {
+		// Wait for outstanding publish acks
+		if n.js != nil {
+			select {
+			case <-n.js.PublishAsyncComplete():
+			case <-time.After(n.config.AckTimeout):
+				log.Printf("⚠️  %d NATS publish(es) still awaiting ack", n.js.PublishAsyncPending())
+			}
+		}
+
 		// Drain connection (flush pending messages)
 		if err := n.conn.Drain(); err != nil {
 			log.Printf("⚠️  Failed to drain NATS connection: %v", err)
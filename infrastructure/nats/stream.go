@@ -0,0 +1,197 @@
+package nats
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+)
+
+// streamConfig builds the JetStream stream configuration from the NATS settings
+func streamConfig(cfg *config.NATSConfig) (*nats.StreamConfig, error) {
+	var retention nats.RetentionPolicy
+	switch cfg.StreamRetention {
+	case "limits":
+		retention = nats.LimitsPolicy // Messages kept until MaxAge; every consumer reads every event
+	case "interest":
+		retention = nats.InterestPolicy // Messages deleted once every consumer acknowledged them
+	case "workqueue":
+		retention = nats.WorkQueuePolicy // Messages deleted after the first ack; a single consumer per subject
+	default:
+		return nil, fmt.Errorf("invalid NATS_STREAM_RETENTION %q (limits, interest or workqueue)", cfg.StreamRetention)
+	}
+
+	var storage nats.StorageType
+	switch cfg.StreamStorage {
+	case "file":
+		storage = nats.FileStorage
+	case "memory":
+		storage = nats.MemoryStorage
+	default:
+		return nil, fmt.Errorf("invalid NATS_STREAM_STORAGE %q (file or memory)", cfg.StreamStorage)
+	}
+
+	if cfg.StreamReplicas < 1 || cfg.StreamReplicas > 5 {
+		return nil, fmt.Errorf("invalid NATS_STREAM_REPLICAS %d (1-5)", cfg.StreamReplicas)
+	}
+	if cfg.StreamMaxAge > 0 && cfg.StreamDuplicates > cfg.StreamMaxAge {
+		return nil, errors.New("NATS_STREAM_DUPLICATE_WINDOW must not exceed NATS_STREAM_MAX_AGE")
+	}
+	if len(cfg.StreamSubjects) == 0 {
+		return nil, errors.New("NATS_STREAM_SUBJECTS must not be empty")
+	}
+	if err := checkSubjectsCovered(cfg); err != nil {
+		return nil, err
+	}
+
+	return &nats.StreamConfig{
+		Name:        cfg.StreamName,
+		Subjects:    cfg.StreamSubjects,
+		Storage:     storage,
+		Retention:   retention,
+		MaxAge:      cfg.StreamMaxAge,
+		Duplicates:  cfg.StreamDuplicates,
+		Replicas:    cfg.StreamReplicas,
+		Description: "User events stream for Auth Service",
+	}, nil
+}
+
+// ValidateStreamConfig reports stream settings that can never work, so startup fails on them
+// instead of falling back to running without NATS
+func ValidateStreamConfig(cfg *config.NATSConfig) error {
+	_, err := streamConfig(cfg)
+	return err
+}
+
+// createOrUpdateStream creates the JetStream stream or brings an existing one in line with the
+// configuration. Settings the server cannot change in place (storage, and retention to or from
+// workqueue) are kept as they are unless NATS_STREAM_RECREATE allows recreating an empty stream,
+// so that no unconsumed events are lost.
+func createOrUpdateStream(js nats.JetStreamContext, cfg *config.NATSConfig) error {
+	streamConfig, err := streamConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Try to get existing stream
+	stream, err := js.StreamInfo(cfg.StreamName)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		// Stream doesn't exist, create it
+		if _, err := js.AddStream(streamConfig); err != nil {
+			return fmt.Errorf("failed to create stream: %w", err)
+		}
+		log.Printf("✅ Created NATS stream: %s (retention: %s, storage: %s, replicas: %d)",
+			cfg.StreamName, cfg.StreamRetention, cfg.StreamStorage, cfg.StreamReplicas)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get stream info: %w", err)
+	}
+
+	if immutable := immutableChanges(&stream.Config, streamConfig); len(immutable) > 0 {
+		if cfg.StreamRecreate && stream.State.Msgs == 0 {
+			return recreateStream(js, streamConfig, immutable)
+		}
+
+		if cfg.StreamRecreate {
+			log.Printf("⚠️  NATS stream %s holds %d message(s) and is not recreated; let consumers drain it, then restart",
+				cfg.StreamName, stream.State.Msgs)
+		} else {
+			log.Printf("⚠️  NATS stream %s cannot change %s in place; keeping the current values. Set NATS_STREAM_RECREATE=true to recreate it once it is empty",
+				cfg.StreamName, strings.Join(immutable, ", "))
+		}
+
+		// Apply the remaining settings on top of the existing ones
+		streamConfig.Storage = stream.Config.Storage
+		streamConfig.Retention = stream.Config.Retention
+	}
+
+	// Stream exists, update it
+	if _, err := js.UpdateStream(streamConfig); err != nil {
+		return fmt.Errorf("failed to update stream: %w", err)
+	}
+	log.Printf("✅ Updated NATS stream: %s (msgs: %d)", cfg.StreamName, stream.State.Msgs)
+
+	return nil
+}
+
+// immutableChanges lists the settings of desired that differ from current and that the
+// server refuses to update in place
+func immutableChanges(current, desired *nats.StreamConfig) []string {
+	var changes []string
+	if current.Storage != desired.Storage {
+		changes = append(changes, fmt.Sprintf("storage (%s → %s)", current.Storage, desired.Storage))
+	}
+	// Limits and interest can be switched; workqueue cannot be entered or left
+	if current.Retention != desired.Retention &&
+		(current.Retention == nats.WorkQueuePolicy || desired.Retention == nats.WorkQueuePolicy) {
+		changes = append(changes, fmt.Sprintf("retention (%s → %s)", current.Retention, desired.Retention))
+	}
+	return changes
+}
+
+// recreateStream deletes an empty stream and creates it with the new configuration.
+// Consumers of the old stream are deleted with it and must be created again.
+func recreateStream(js nats.JetStreamContext, streamConfig *nats.StreamConfig, changes []string) error {
+	if err := js.DeleteStream(streamConfig.Name); err != nil {
+		return fmt.Errorf("failed to delete stream for recreation: %w", err)
+	}
+	if _, err := js.AddStream(streamConfig); err != nil {
+		return fmt.Errorf("failed to recreate stream: %w", err)
+	}
+	log.Printf("✅ Recreated NATS stream: %s (%s); consumers must be created again",
+		streamConfig.Name, strings.Join(changes, ", "))
+	return nil
+}
+
+// checkSubjectsCovered rejects stream subjects that leave an event subject uncaptured,
+// since publishing on it would fail at runtime
+func checkSubjectsCovered(cfg *config.NATSConfig) error {
+	var uncovered []string
+	for _, schema := range services.EventSchemas() {
+		topics := []string{schema.Topic, services.VersionedTopic(schema.Version, schema.Topic)}
+		for _, previous := range schema.Previous {
+			topics = append(topics, services.VersionedTopic(previous.Version, schema.Topic))
+		}
+
+		for _, topic := range topics {
+			subject := cfg.Subject + "." + topic
+			if !coversSubject(cfg.StreamSubjects, subject) {
+				uncovered = append(uncovered, subject)
+			}
+		}
+	}
+
+	if len(uncovered) > 0 {
+		return fmt.Errorf("NATS_STREAM_SUBJECTS do not capture %s", strings.Join(uncovered, ", "))
+	}
+	return nil
+}
+
+// coversSubject reports whether any of the patterns matches subject. Patterns use the NATS
+// wildcards: "*" matches one token and a trailing ">" matches one or more tokens.
+func coversSubject(patterns []string, subject string) bool {
+	tokens := strings.Split(subject, ".")
+	for _, pattern := range patterns {
+		if matchesSubject(strings.Split(pattern, "."), tokens) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSubject(pattern, tokens []string) bool {
+	for i, token := range pattern {
+		if token == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) || (token != "*" && token != tokens[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(tokens)
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repositories.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// auditChainLockKey is the advisory lock that serializes appends to the hash chain
+const auditChainLockKey = 7_031_944_201
+
+func (r *auditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	// Hash exactly what will be read back: microsecond timestamps and JSON-decoded metadata
+	event.CreatedAt = event.CreatedAt.UTC().Truncate(time.Microsecond)
+	if event.Metadata != nil {
+		raw, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return err
+		}
+		var metadata datatypes.JSONMap
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return err
+		}
+		event.Metadata = metadata
+	}
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditChainLockKey).Error; err != nil {
+			return err
+		}
+
+		head, err := chainHead(tx)
+		if err != nil {
+			return err
+		}
+		event.PrevHash = ""
+		if head != nil {
+			event.PrevHash = head.Hash
+		}
+
+		event.Hash, err = event.ComputeHash()
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(event).Error
+	})
+}
+
+func (r *auditRepository) Search(ctx context.Context, filter *repositories.AuditFilter) ([]*models.AuditEvent, error) {
+	query := r.db.WithContext(ctx).Model(&models.AuditEvent{})
+
+	if filter.EventType != "" {
+		if strings.HasSuffix(filter.EventType, ".") {
+			query = query.Where("event_type LIKE ?", escapeLike(filter.EventType)+"%")
+		} else {
+			query = query.Where("event_type = ?", filter.EventType)
+		}
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.SubjectID != nil {
+		query = query.Where("subject_id = ?", *filter.SubjectID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("(actor_id = ? OR subject_id = ?)", *filter.UserID, *filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.BeforeCreatedAt != nil && filter.BeforeID != nil {
+		query = query.Where("(created_at, id) < (?, ?)", *filter.BeforeCreatedAt, *filter.BeforeID)
+	}
+
+	var events []*models.AuditEvent
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *auditRepository) ListChain(ctx context.Context, afterSequence int64, limit int) ([]*models.AuditEvent, error) {
+	var events []*models.AuditEvent
+	err := r.db.WithContext(ctx).
+		Where("sequence > ?", afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *auditRepository) GetChainHead(ctx context.Context) (*models.AuditEvent, error) {
+	return chainHead(r.db.WithContext(ctx))
+}
+
+func (r *auditRepository) CreateCheckpoint(ctx context.Context, checkpoint *models.AuditCheckpoint) error {
+	return r.db.WithContext(ctx).Create(checkpoint).Error
+}
+
+func (r *auditRepository) GetLatestCheckpoint(ctx context.Context) (*models.AuditCheckpoint, error) {
+	var checkpoint models.AuditCheckpoint
+	err := r.db.WithContext(ctx).Order("sequence DESC").First(&checkpoint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *auditRepository) ListCheckpoints(ctx context.Context) ([]*models.AuditCheckpoint, error) {
+	var checkpoints []*models.AuditCheckpoint
+	err := r.db.WithContext(ctx).Order("sequence ASC").Find(&checkpoints).Error
+	return checkpoints, err
+}
+
+// chainHead returns the newest event that is part of the chain.
+// Events recorded before chaining was introduced have no hash and are skipped.
+func chainHead(db *gorm.DB) (*models.AuditEvent, error) {
+	var head models.AuditEvent
+	err := db.Where("hash <> ''").Order("sequence DESC").First(&head).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &head, nil
+}
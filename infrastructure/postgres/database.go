@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -36,15 +37,129 @@ func Migrate(db *gorm.DB) error {
 	var usersTableExists bool
 	db.Raw("SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_name = 'users')").Scan(&usersTableExists)
 
-	if usersTableExists {
-		// Table already exists, skip migration
-		// This prevents issues with existing table structures
-		return nil
+	// Core tables are only auto-migrated on a fresh database.
+	// This prevents issues with existing table structures
+	if !usersTableExists {
+		if err := db.AutoMigrate(
+			&models.User{},
+			&models.OAuthProvider{},
+		); err != nil {
+			return err
+		}
+	}
+
+	// Additive tables introduced after the core schema are always migrated
+	if err := db.AutoMigrate(
+		&models.Permission{},
+		&models.Role{},
+		&models.UserRole{},
+		&models.Organization{},
+		&models.OrganizationMember{},
+		&models.OrganizationInvitation{},
+		&models.PersonalAccessToken{},
+		&models.PasswordResetToken{},
+		&models.UserSuspension{},
+		&models.ImpersonationSession{},
+		&models.UserSession{},
+		&models.KnownDevice{},
+		&models.LoginAlert{},
+		&models.AuditEvent{},
+		&models.AuditCheckpoint{},
+		&models.OutboxEvent{},
+		&models.DeadLetterEvent{},
+		&models.WebhookEndpoint{},
+		&models.WebhookDelivery{},
+	); err != nil {
+		return err
+	}
+
+	ensureUserSearchIndexes(db)
+	ensureAuditLogAppendOnly(db)
+
+	return SeedRBAC(db)
+}
+
+// ensureUserSearchIndexes adds the indexes used by user search and keyset pagination.
+// pg_trgm needs extension privileges, so failures are logged and search falls back to scans.
+func ensureUserSearchIndexes(db *gorm.DB) {
+	statements := []string{
+		"CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at, id)",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_users_username_trgm ON users USING gin (username gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_users_display_name_trgm ON users USING gin (display_name gin_trgm_ops)",
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Printf("Warning: user search index not created (%s): %v", statement, err)
+			return
+		}
 	}
+}
 
-	// Only run AutoMigrate if tables don't exist
-	return db.AutoMigrate(
-		&models.User{},
-		&models.OAuthProvider{},
-	)
-}
\ No newline at end of file
+// ensureAuditLogAppendOnly makes the database reject changes to recorded audit events
+// and checkpoints, so even a compromised application account cannot rewrite history.
+// TRUNCATE is left to the table owner for retention jobs.
+func ensureAuditLogAppendOnly(db *gorm.DB) {
+	statements := []string{
+		"CREATE INDEX IF NOT EXISTS idx_audit_events_created_at_id ON audit_events (created_at, id)",
+		`CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+		END;
+		$$ LANGUAGE plpgsql`,
+	}
+	for _, table := range []string{"audit_events", "audit_checkpoints"} {
+		statements = append(statements,
+			fmt.Sprintf("DROP TRIGGER IF EXISTS %s_append_only ON %s", table, table),
+			fmt.Sprintf(`CREATE TRIGGER %s_append_only BEFORE UPDATE OR DELETE ON %s
+		FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()`, table, table),
+		)
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Printf("Warning: audit log protection not installed: %v", err)
+			return
+		}
+	}
+}
+
+// SeedRBAC ensures the permission catalogue and the system roles exist.
+// The admin role is granted every known permission so new permissions reach it automatically.
+func SeedRBAC(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		allPermissions := make([]models.Permission, 0, len(models.PermissionCatalogue))
+		for name, description := range models.PermissionCatalogue {
+			permission := models.Permission{Name: name}
+			if err := tx.Where(models.Permission{Name: name}).
+				Attrs(models.Permission{Description: description}).
+				FirstOrCreate(&permission).Error; err != nil {
+				return fmt.Errorf("failed to seed permission %s: %v", name, err)
+			}
+			allPermissions = append(allPermissions, permission)
+		}
+
+		systemRoles := map[string]string{
+			models.RoleAdmin: "Full administrative access",
+			models.RoleUser:  "Default role for registered users",
+		}
+		for name, description := range systemRoles {
+			role := models.Role{Name: name}
+			if err := tx.Where(models.Role{Name: name}).
+				Attrs(models.Role{Description: description, IsSystem: true}).
+				FirstOrCreate(&role).Error; err != nil {
+				return fmt.Errorf("failed to seed role %s: %v", name, err)
+			}
+
+			if name == models.RoleAdmin {
+				if err := tx.Model(&role).Association("Permissions").Replace(allPermissions); err != nil {
+					return fmt.Errorf("failed to grant admin permissions: %v", err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
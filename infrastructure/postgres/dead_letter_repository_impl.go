@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type deadLetterRepository struct {
+	db *gorm.DB
+}
+
+func NewDeadLetterRepository(db *gorm.DB) repositories.DeadLetterRepository {
+	return &deadLetterRepository{db: db}
+}
+
+func (r *deadLetterRepository) Create(ctx context.Context, event *models.DeadLetterEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(event).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", event.ID).Delete(&models.OutboxEvent{}).Error
+	})
+}
+
+func (r *deadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetterEvent, error) {
+	var event models.DeadLetterEvent
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *deadLetterRepository) List(ctx context.Context, filter *repositories.DeadLetterFilter) ([]*models.DeadLetterEvent, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DeadLetterEvent{})
+	if filter.Topic != "" {
+		query = query.Where("topic = ?", filter.Topic)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.AggregateID != nil {
+		query = query.Where("aggregate_id = ?", *filter.AggregateID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*models.DeadLetterEvent
+	err := query.Order("created_at, id").Offset(filter.Offset).Limit(filter.Limit).Find(&events).Error
+	return events, total, err
+}
+
+func (r *deadLetterRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event models.DeadLetterEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&event).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		outboxEvent := &models.OutboxEvent{
+			ID:            event.ID,
+			AggregateID:   event.AggregateID,
+			Topic:         event.Topic,
+			Payload:       event.Payload,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		if err := tx.Create(outboxEvent).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.DeadLetterEvent{}).Error
+	})
+}
+
+func (r *deadLetterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.DeadLetterEvent{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *deadLetterRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.DeadLetterEvent{}).Count(&count).Error
+	return count, err
+}
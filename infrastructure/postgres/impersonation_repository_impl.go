@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type impersonationRepository struct {
+	db *gorm.DB
+}
+
+func NewImpersonationRepository(db *gorm.DB) repositories.ImpersonationRepository {
+	return &impersonationRepository{db: db}
+}
+
+func (r *impersonationRepository) Create(ctx context.Context, session *models.ImpersonationSession) error {
+	return r.db.WithContext(ctx).Omit("Actor", "TargetUser").Create(session).Error
+}
+
+func (r *impersonationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ImpersonationSession, error) {
+	var session models.ImpersonationSession
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *impersonationRepository) ListByTarget(ctx context.Context, targetUserID uuid.UUID) ([]*models.ImpersonationSession, error) {
+	var sessions []*models.ImpersonationSession
+	err := r.db.WithContext(ctx).
+		Where("target_user_id = ?", targetUserID).
+		Order("started_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *impersonationRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.ImpersonationSession, error) {
+	var sessions []*models.ImpersonationSession
+	err := r.db.WithContext(ctx).
+		Where("ended_at IS NULL AND expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *impersonationRepository) End(ctx context.Context, id uuid.UUID, at time.Time, endedBy *uuid.UUID, reason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ImpersonationSession{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"ended_at":   at,
+			"ended_by":   endedBy,
+			"end_reason": reason,
+			"updated_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type knownDeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewKnownDeviceRepository(db *gorm.DB) repositories.KnownDeviceRepository {
+	return &knownDeviceRepository{db: db}
+}
+
+func (r *knownDeviceRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.KnownDevice, error) {
+	var devices []*models.KnownDevice
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
+
+func (r *knownDeviceRepository) Upsert(ctx context.Context, device *models.KnownDevice) error {
+	return r.db.WithContext(ctx).Omit("User").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "fingerprint"}, {Name: "country"}},
+			DoUpdates: clause.AssignmentColumns([]string{"device_name", "ip_address", "last_seen_at"}),
+		}).
+		Create(device).Error
+}
+
+func (r *knownDeviceRepository) CreateAlert(ctx context.Context, alert *models.LoginAlert) error {
+	return r.db.WithContext(ctx).Omit("User").Create(alert).Error
+}
+
+func (r *knownDeviceRepository) GetAlertByTokenHash(ctx context.Context, tokenHash string) (*models.LoginAlert, error) {
+	var alert models.LoginAlert
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&alert).Error
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+func (r *knownDeviceRepository) MarkAlertUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.LoginAlert{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) repositories.OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization, owner *models.OrganizationMember, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = org.ID
+		if err := tx.Omit("Organization", "User").Create(owner).Error; err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationRepository) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	var org models.Organization
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	return r.db.WithContext(ctx).Save(org).Error
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", id).Delete(&models.OrganizationInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("organization_id = ?", id).Delete(&models.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", id).Delete(&models.Organization{}).Error; err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&member).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationMember, error) {
+	var members []*models.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("created_at").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) ListMembershipsByUser(ctx context.Context, userID uuid.UUID) ([]*models.OrganizationMember, error) {
+	var members []*models.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *organizationRepository) GetLastActiveMembership(ctx context.Context, userID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("last_active_at DESC NULLS LAST, created_at").
+		First(&member).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) error {
+	return r.db.WithContext(ctx).Omit("Organization", "User").Create(member).Error
+}
+
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.OrganizationMember{}).
+			Where("organization_id = ? AND user_id = ?", orgID, userID).
+			Updates(map[string]interface{}{"role": role, "updated_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
+
+func (r *organizationRepository) TouchMember(ctx context.Context, orgID, userID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Update("last_active_at", at).Error
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("organization_id = ? AND user_id = ?", orgID, userID).
+			Delete(&models.OrganizationMember{}).Error
+		if err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
+
+func (r *organizationRepository) CountMembersWithRole(ctx context.Context, orgID uuid.UUID, role string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", orgID, role).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error {
+	return r.db.WithContext(ctx).Omit("Organization").Create(invitation).Error
+}
+
+func (r *organizationRepository) GetInvitationByID(ctx context.Context, id uuid.UUID) (*models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	err := r.db.WithContext(ctx).Preload("Organization").Where("id = ?", id).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *organizationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.OrganizationInvitation, error) {
+	var invitation models.OrganizationInvitation
+	err := r.db.WithContext(ctx).Preload("Organization").Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *organizationRepository) ListPendingInvitations(ctx context.Context, orgID uuid.UUID) ([]*models.OrganizationInvitation, error) {
+	var invitations []*models.OrganizationInvitation
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", orgID, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *organizationRepository) UpdateInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error {
+	return r.db.WithContext(ctx).Omit("Organization").Save(invitation).Error
+}
+
+func (r *organizationRepository) AcceptInvitation(ctx context.Context, invitation *models.OrganizationInvitation, member *models.OrganizationMember, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Guard against concurrent acceptance of the same invitation
+		result := tx.Model(&models.OrganizationInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{
+				"accepted_at": invitation.AcceptedAt,
+				"accepted_by": invitation.AcceptedBy,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("invitation is no longer pending")
+		}
+
+		if err := tx.Omit("Organization", "User").Create(member).Error; err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) repositories.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// createOutboxEvents stores events in the transaction of the change they describe
+func createOutboxEvents(tx *gorm.DB, events []*models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(events).Error
+}
+
+func (r *outboxRepository) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.OutboxEvent, error) {
+	var events []*models.OutboxEvent
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several relays claim disjoint batches; an earlier pending event of the
+		// same aggregate blocks later ones even while it is leased by another relay
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND next_attempt_at <= ?", now).
+			Where(`NOT EXISTS (SELECT 1 FROM outbox_events earlier
+				WHERE earlier.aggregate_id = outbox_events.aggregate_id
+				AND earlier.published_at IS NULL
+				AND earlier.sequence < outbox_events.sequence)`).
+			Order("sequence").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		return tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"published_at": at,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   "",
+		}).Error
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	if len(lastError) > 500 {
+		lastError = lastError[:500]
+	}
+	return r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+func (r *outboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *outboxRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("published_at IS NULL").
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) repositories.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db}
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *models.PersonalAccessToken) error {
+	return r.db.WithContext(ctx).Omit("User").Create(token).Error
+}
+
+func (r *personalAccessTokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *personalAccessTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *personalAccessTokenRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.PersonalAccessToken, error) {
+	var tokens []*models.PersonalAccessToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *personalAccessTokenRepository) CountActiveByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *personalAccessTokenRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *personalAccessTokenRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *personalAccessTokenRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	// UpdateColumn skips the UpdatedAt hook; usage is not a modification
+	return r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) repositories.RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+	var role models.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Where("id = ?", id).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.db.WithContext(ctx).Preload("Permissions").Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *models.Role, permissions []models.Permission) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		if permissions == nil {
+			return nil
+		}
+		return tx.Model(role).Association("Permissions").Replace(permissions)
+	})
+	if err != nil {
+		return err
+	}
+
+	if permissions != nil {
+		role.Permissions = permissions
+	}
+	return nil
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		role := &models.Role{ID: id}
+		if err := tx.Model(role).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(role).Error
+	})
+}
+
+func (r *roleRepository) ListHolderIDs(ctx context.Context, role *models.Role) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id IN (?) OR role = ?", r.db.Model(&models.UserRole{}).Select("user_id").Where("role_id = ?", role.ID), role.Name).
+		Pluck("id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context) ([]*models.Permission, error) {
+	var permissions []*models.Permission
+	err := r.db.WithContext(ctx).Order("name").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *roleRepository) GetPermissionsByNames(ctx context.Context, names []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.db.WithContext(ctx).Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *roleRepository) AssignToUser(ctx context.Context, userRole *models.UserRole) error {
+	// Assigning an already-held role is a no-op
+	return r.db.WithContext(ctx).
+		Omit("User", "Role").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(userRole).Error
+}
+
+func (r *roleRepository) RemoveFromUser(ctx context.Context, userID, roleID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&models.UserRole{}).Error
+}
+
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.db.WithContext(ctx).
+		Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error
+	return roles, err
+}
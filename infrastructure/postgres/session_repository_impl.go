@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repositories.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	return r.db.WithContext(ctx).Omit("User").Create(session).Error
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.UserSession, error) {
+	var session models.UserSession
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.UserSession, error) {
+	var sessions []*models.UserSession
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) HasIPAddress(ctx context.Context, userID uuid.UUID, ip string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("user_id = ? AND ip_address = ?", userID, ip).
+		Limit(1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *sessionRepository) GetLatestByUser(ctx context.Context, userID uuid.UUID) (*models.UserSession, error) {
+	var sessions []*models.UserSession
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Limit(1).
+		Find(&sessions).Error
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, id uuid.UUID, at, staleBefore time.Time, ipAddress string) error {
+	updates := map[string]interface{}{"last_seen_at": at}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL AND last_seen_at < ?", id, staleBefore).
+		Updates(updates).Error
+}
+
+func (r *sessionRepository) Extend(ctx context.Context, id uuid.UUID, at, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"last_seen_at": at,
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time, reason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     at,
+			"revoked_reason": reason,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time, reason string) error {
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at).
+		Updates(map[string]interface{}{
+			"revoked_at":     at,
+			"revoked_reason": reason,
+		}).Error
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type suspensionRepository struct {
+	db *gorm.DB
+}
+
+func NewSuspensionRepository(db *gorm.DB) repositories.SuspensionRepository {
+	return &suspensionRepository{db: db}
+}
+
+func (r *suspensionRepository) Create(ctx context.Context, suspension *models.UserSuspension, events ...*models.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(suspension).Error; err != nil {
+			return err
+		}
+		return createOutboxEvents(tx, events)
+	})
+}
+
+func (r *suspensionRepository) GetActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) (*models.UserSuspension, error) {
+	var suspension models.UserSuspension
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND lifted_at IS NULL AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", userID, now, now).
+		Order("starts_at DESC").
+		First(&suspension).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &suspension, nil
+}
+
+func (r *suspensionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.UserSuspension, error) {
+	var suspensions []*models.UserSuspension
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("starts_at DESC").
+		Find(&suspensions).Error
+	return suspensions, err
+}
+
+func (r *suspensionRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.UserSuspension, error) {
+	var suspensions []*models.UserSuspension
+	err := r.db.WithContext(ctx).
+		Where("lifted_at IS NULL AND ends_at IS NOT NULL AND ends_at <= ?", now).
+		Order("ends_at ASC").
+		Limit(limit).
+		Find(&suspensions).Error
+	return suspensions, err
+}
+
+func (r *suspensionRepository) Lift(ctx context.Context, id uuid.UUID, at time.Time, liftedBy *uuid.UUID, reason string, events ...*models.OutboxEvent) (bool, error) {
+	lifted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserSuspension{}).
+			Where("id = ? AND lifted_at IS NULL", id).
+			Updates(map[string]interface{}{
+				"lifted_at":   at,
+				"lifted_by":   liftedBy,
+				"lift_reason": reason,
+				"updated_at":  at,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		lifted = true
+		return createOutboxEvents(tx, events)
+	})
+	if err != nil {
+		return false, err
+	}
+	return lifted, nil
+}
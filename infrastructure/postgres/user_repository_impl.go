@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gofiber-template/domain/models"
@@ -16,8 +18,10 @@ func NewUserRepository(db *gorm.DB) repositories.UserRepository {
 	return &UserRepositoryImpl{db: db}
 }
 
-func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *models.User, events ...*models.OutboxEvent) error {
+	return r.withEvents(ctx, events, func(tx *gorm.DB) error {
+		return tx.Create(user).Error
+	})
 }
 
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
@@ -47,12 +51,36 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 	return &user, nil
 }
 
-func (r *UserRepositoryImpl) Update(ctx context.Context, id uuid.UUID, user *models.User) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Updates(user).Error
+func (r *UserRepositoryImpl) Update(ctx context.Context, id uuid.UUID, user *models.User, events ...*models.OutboxEvent) error {
+	return r.withEvents(ctx, events, func(tx *gorm.DB) error {
+		return tx.Where("id = ?", id).Updates(user).Error
+	})
 }
 
-func (r *UserRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.User{}).Error
+func (r *UserRepositoryImpl) UpdateFields(ctx context.Context, id uuid.UUID, fields map[string]interface{}, events ...*models.OutboxEvent) error {
+	return r.withEvents(ctx, events, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).Where("id = ?", id).Updates(fields).Error
+	})
+}
+
+func (r *UserRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	return r.withEvents(ctx, events, func(tx *gorm.DB) error {
+		return tx.Where("id = ?", id).Delete(&models.User{}).Error
+	})
+}
+
+// withEvents runs write and stores the outbox events in one transaction, so an event exists
+// exactly when its change was committed
+func (r *UserRepositoryImpl) withEvents(ctx context.Context, events []*models.OutboxEvent, write func(tx *gorm.DB) error) error {
+	if len(events) == 0 {
+		return write(r.db.WithContext(ctx))
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := write(tx); err != nil {
+			return err
+		}
+		return tx.Create(events).Error
+	})
 }
 
 func (r *UserRepositoryImpl) List(ctx context.Context, offset, limit int) ([]*models.User, error) {
@@ -65,4 +93,93 @@ func (r *UserRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&models.User{}).Count(&count).Error
 	return count, err
-}
\ No newline at end of file
+}
+// userSortColumns maps sort keys to ORDER BY expressions; NULL login times sort as the epoch
+var userSortColumns = map[string]string{
+	repositories.UserSortCreatedAt:   "created_at",
+	repositories.UserSortUpdatedAt:   "updated_at",
+	repositories.UserSortLastLoginAt: "COALESCE(last_login_at, to_timestamp(0))",
+	repositories.UserSortEmail:       "email",
+	repositories.UserSortUsername:    "username",
+}
+
+func (r *UserRepositoryImpl) Search(ctx context.Context, filter *repositories.UserFilter) ([]*models.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.User{})
+
+	if filter.Search != "" {
+		// ILIKE can use the pg_trgm indexes created by Migrate when the extension is available
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where(
+			"(email ILIKE ? OR username ILIKE ? OR display_name ILIKE ?)",
+			pattern, pattern, pattern,
+		)
+	}
+	if filter.Role != "" {
+		// Matches the legacy primary role or an assigned role, as ResolveAuthorization does
+		query = query.Where(
+			"(users.role = ? OR EXISTS (SELECT 1 FROM user_roles JOIN roles ON roles.id = user_roles.role_id WHERE user_roles.user_id = users.id AND roles.name = ?))",
+			filter.Role, filter.Role,
+		)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Provider == "local" {
+		query = query.Where("is_o_auth_user = ?", false)
+	} else if filter.Provider != "" {
+		query = query.Where("o_auth_provider = ?", filter.Provider)
+	}
+	if filter.EmailVerified != nil {
+		query = query.Where("email_verified = ?", *filter.EmailVerified)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.LastLoginFrom != nil {
+		query = query.Where("last_login_at >= ?", *filter.LastLoginFrom)
+	}
+	if filter.LastLoginTo != nil {
+		query = query.Where("last_login_at < ?", *filter.LastLoginTo)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := userSortColumns[filter.SortBy]
+	if !ok {
+		column = userSortColumns[repositories.UserSortCreatedAt]
+	}
+	direction, comparator := "ASC", ">"
+	if filter.SortDesc {
+		direction, comparator = "DESC", "<"
+	}
+
+	page := query.Session(&gorm.Session{})
+	if filter.AfterID != nil {
+		// Row comparison keeps the order stable when sort values repeat
+		page = page.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), filter.AfterValue, *filter.AfterID)
+	} else if filter.Offset > 0 {
+		page = page.Offset(filter.Offset)
+	}
+
+	var users []*models.User
+	err := page.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(filter.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) repositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&endpoint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	err := r.db.WithContext(ctx).Order("created_at, id").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) ListActiveEndpoints(ctx context.Context) ([]*models.WebhookEndpoint, error) {
+	var endpoints []*models.WebhookEndpoint
+	err := r.db.WithContext(ctx).Where("is_active = ?", true).Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, id uuid.UUID, fields map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookEndpoint{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.WebhookEndpoint{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) RecordFailure(ctx context.Context, id uuid.UUID) (int, error) {
+	var endpoint models.WebhookEndpoint
+	err := r.db.WithContext(ctx).Model(&endpoint).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "consecutive_failures"}}}).
+		Where("id = ?", id).
+		Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+	return endpoint.ConsecutiveFailures, err
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookEndpoint{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Omit("Endpoint").
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "endpoint_id"}, {Name: "event_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "redelivery_of IS NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(&deliveries).Error
+}
+
+func (r *webhookRepository) CreateRedelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Omit("Endpoint").Create(delivery).Error
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter *repositories.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", filter.EndpointID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	err := query.Order("created_at DESC, id").Offset(filter.Offset).Limit(filter.Limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several dispatchers claim disjoint batches; deliveries of disabled
+		// endpoints stay pending until the endpoint is enabled again
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED", Table: clause.Table{Name: "webhook_deliveries"}}).
+			Preload("Endpoint").
+			Joins("JOIN webhook_endpoints ON webhook_endpoints.id = webhook_deliveries.endpoint_id AND webhook_endpoints.is_active").
+			Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+			Order("webhook_deliveries.next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"last_response":    delivery.LastResponse,
+			"last_duration_ms": delivery.LastDurationMs,
+			"delivered_at":     delivery.DeliveredAt,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status <> ? AND created_at < ?", models.WebhookDeliveryPending, before).
+		Delete(&models.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
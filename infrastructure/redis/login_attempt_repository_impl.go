@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gofiber-template/domain/repositories"
+)
+
+const (
+	loginFailuresKeyPrefix    = "auth:login:failures:"
+	loginLastFailureKeyPrefix = "auth:login:last_failure:"
+	loginLockedKeyPrefix      = "auth:login:locked:"
+)
+
+type loginAttemptRepository struct {
+	redis *RedisClient
+}
+
+func NewLoginAttemptRepository(client *RedisClient) repositories.LoginAttemptRepository {
+	return &loginAttemptRepository{redis: client}
+}
+
+func (r *loginAttemptRepository) GetState(ctx context.Context, key string) (*repositories.LoginAttemptState, error) {
+	values, err := r.redis.client.MGet(ctx,
+		loginFailuresKeyPrefix+key,
+		loginLastFailureKeyPrefix+key,
+		loginLockedKeyPrefix+key,
+	).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &repositories.LoginAttemptState{}
+	if failures, ok := values[0].(string); ok {
+		state.Failures, _ = strconv.ParseInt(failures, 10, 64)
+	}
+	state.LastFailureAt = parseUnixMilli(values[1])
+	state.LockedUntil = parseUnixMilli(values[2])
+
+	return state, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (int64, error) {
+	var failures *redis.IntCmd
+	_, err := r.redis.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Incr(ctx, loginFailuresKeyPrefix+key)
+		pipe.PExpire(ctx, loginFailuresKeyPrefix+key, window)
+		pipe.Set(ctx, loginLastFailureKeyPrefix+key, at.UnixMilli(), window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return failures.Val(), nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.redis.client.Set(ctx, loginLockedKeyPrefix+key, until.UnixMilli(), ttl).Err()
+}
+
+func (r *loginAttemptRepository) Clear(ctx context.Context, key string) error {
+	return r.redis.client.Del(ctx,
+		loginFailuresKeyPrefix+key,
+		loginLastFailureKeyPrefix+key,
+		loginLockedKeyPrefix+key,
+	).Err()
+}
+
+// parseUnixMilli reads a millisecond timestamp returned by MGET (nil when the key is missing)
+func parseUnixMilli(value interface{}) *time.Time {
+	raw, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	ms, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil
+	}
+	t := time.UnixMilli(ms)
+	return &t
+}
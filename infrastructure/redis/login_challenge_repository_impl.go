@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gofiber-template/domain/repositories"
+)
+
+const (
+	loginChallengeKeyPrefix         = "auth:login:challenge:"
+	loginChallengeFailuresKeyPrefix = "auth:login:challenge_failures:"
+)
+
+type loginChallengeRepository struct {
+	redis *RedisClient
+}
+
+func NewLoginChallengeRepository(client *RedisClient) repositories.LoginChallengeRepository {
+	return &loginChallengeRepository{redis: client}
+}
+
+func (r *loginChallengeRepository) Create(ctx context.Context, id string, challenge *repositories.LoginChallenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("challenge already expired")
+	}
+	return r.redis.Set(ctx, loginChallengeKeyPrefix+id, challenge, ttl)
+}
+
+func (r *loginChallengeRepository) Get(ctx context.Context, id string) (*repositories.LoginChallenge, error) {
+	var challenge repositories.LoginChallenge
+	if err := r.redis.Get(ctx, loginChallengeKeyPrefix+id, &challenge); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+func (r *loginChallengeRepository) RecordFailure(ctx context.Context, id string) (int64, error) {
+	var failures *redis.IntCmd
+	_, err := r.redis.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Incr(ctx, loginChallengeFailuresKeyPrefix+id)
+		pipe.Expire(ctx, loginChallengeFailuresKeyPrefix+id, time.Hour)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return failures.Val(), nil
+}
+
+func (r *loginChallengeRepository) Delete(ctx context.Context, id string) (bool, error) {
+	deleted, err := r.redis.client.Del(ctx, loginChallengeKeyPrefix+id).Result()
+	if err != nil {
+		return false, err
+	}
+	r.redis.client.Del(ctx, loginChallengeFailuresKeyPrefix+id)
+	return deleted == 1, nil
+}
@@ -0,0 +1,70 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gofiber-template/pkg/ratelimit"
+)
+
+const rateLimitKeyPrefix = "auth:ratelimit:"
+
+// slidingWindowScript keeps one sorted-set member per request scored by its time in ms.
+// It returns {allowed, remaining, reset_ms}.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	count = count + 1
+	allowed = 1
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local reset = window
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+type rateLimiter struct {
+	redis *RedisClient
+}
+
+// NewRateLimiter creates a sliding window rate limiter shared by all instances
+func NewRateLimiter(client *RedisClient) ratelimit.Limiter {
+	return &rateLimiter{redis: client}
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*ratelimit.Result, error) {
+	values, err := slidingWindowScript.Run(ctx, l.redis.client,
+		[]string{rateLimitKeyPrefix + key},
+		time.Now().UnixMilli(), window.Milliseconds(), limit, uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 3 {
+		return nil, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	return &ratelimit.Result{
+		Allowed:   values[0] == 1,
+		Limit:     limit,
+		Remaining: int(values[1]),
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gofiber-template/domain/repositories"
+)
+
+const (
+	revokedTokenKeyPrefix   = "auth:revoked:jti:"
+	revokedSessionKeyPrefix = "auth:revoked:session:"
+	revokedUserKeyPrefix    = "auth:revoked:user:"
+	revokedMemberKeyPrefix  = "auth:revoked:membership:"
+)
+
+type tokenRevocationRepository struct {
+	redis *RedisClient
+	// maxTokenTTL bounds how long a user-wide revocation must be remembered
+	maxTokenTTL time.Duration
+}
+
+func NewTokenRevocationRepository(client *RedisClient, maxTokenTTL time.Duration) repositories.TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		redis:       client,
+		maxTokenTTL: maxTokenTTL,
+	}
+}
+
+func (r *tokenRevocationRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// Already expired, nothing to remember
+		return nil
+	}
+	return r.redis.Set(ctx, revokedTokenKeyPrefix+jti, time.Now().Unix(), ttl)
+}
+
+func (r *tokenRevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	return r.redis.Exists(ctx, revokedTokenKeyPrefix+jti)
+}
+
+func (r *tokenRevocationRepository) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.redis.Set(ctx, revokedSessionKeyPrefix+sessionID, time.Now().Unix(), ttl)
+}
+
+func (r *tokenRevocationRepository) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	if sessionID == "" {
+		return false, nil
+	}
+	return r.redis.Exists(ctx, revokedSessionKeyPrefix+sessionID)
+}
+
+func (r *tokenRevocationRepository) RevokeUserTokens(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	return r.redis.Set(ctx, revokedUserKeyPrefix+userID.String(), before.UnixMilli(), r.maxTokenTTL)
+}
+
+func (r *tokenRevocationRepository) GetUserTokensRevokedBefore(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	return r.getCutoff(ctx, revokedUserKeyPrefix+userID.String())
+}
+
+func (r *tokenRevocationRepository) RevokeMembershipTokens(ctx context.Context, userID, orgID uuid.UUID, before time.Time) error {
+	return r.redis.Set(ctx, membershipKey(userID, orgID), before.UnixMilli(), r.maxTokenTTL)
+}
+
+func (r *tokenRevocationRepository) GetMembershipTokensRevokedBefore(ctx context.Context, userID, orgID uuid.UUID) (*time.Time, error) {
+	return r.getCutoff(ctx, membershipKey(userID, orgID))
+}
+
+func (r *tokenRevocationRepository) getCutoff(ctx context.Context, key string) (*time.Time, error) {
+	// Stored in milliseconds, the precision of the iat_ms claim
+	var unixMilli int64
+	if err := r.redis.Get(ctx, key, &unixMilli); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	before := time.UnixMilli(unixMilli)
+	return &before, nil
+}
+
+func membershipKey(userID, orgID uuid.UUID) string {
+	return revokedMemberKeyPrefix + userID.String() + ":" + orgID.String()
+}
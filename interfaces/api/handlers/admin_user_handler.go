@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AdminUserHandler struct {
+	adminUserService services.AdminUserService
+}
+
+func NewAdminUserHandler(adminUserService services.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminUserService: adminUserService,
+	}
+}
+
+func (h *AdminUserHandler) GetUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	user, err := h.adminUserService.GetUser(c.UserContext(), userID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "User not found")
+	}
+
+	return utils.SuccessResponse(c, "User retrieved successfully", dto.UserToAdminUserResponse(user))
+}
+
+func (h *AdminUserHandler) UpdateUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.AdminUpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	user, err := h.adminUserService.UpdateUser(c.UserContext(), actor.ID, userID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User update failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User updated successfully", dto.UserToAdminUserResponse(user))
+}
+
+func (h *AdminUserHandler) DeleteUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.adminUserService.DeleteUser(c.UserContext(), actor.ID, userID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User deletion failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User deleted successfully", nil)
+}
+
+func (h *AdminUserHandler) ActivateUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	user, err := h.adminUserService.ActivateUser(c.UserContext(), actor.ID, userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User activation failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User activated successfully", dto.UserToAdminUserResponse(user))
+}
+
+func (h *AdminUserHandler) DeactivateUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	// The body is optional
+	var req dto.DeactivateUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	user, err := h.adminUserService.DeactivateUser(c.UserContext(), actor.ID, userID, req.Reason)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User deactivation failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User deactivated successfully", dto.UserToAdminUserResponse(user))
+}
+
+func (h *AdminUserHandler) ChangeRole(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.ChangeUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	user, err := h.adminUserService.ChangeRole(c.UserContext(), actor.ID, userID, req.Role)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role change failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User role changed successfully", dto.UserToAdminUserResponse(user))
+}
+
+func (h *AdminUserHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.adminUserService.ForcePasswordReset(c.UserContext(), actor.ID, userID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Password reset failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Password reset required; a reset link has been emailed to the user", nil)
+}
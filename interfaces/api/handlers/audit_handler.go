@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+func (h *AuditHandler) ListEvents(c *fiber.Ctx) error {
+	var query dto.AuditEventQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	events, nextCursor, err := h.auditService.Search(c.UserContext(), &query)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve audit events", err)
+	}
+
+	eventResponses := make([]dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		eventResponses[i] = *dto.AuditEventToResponse(event)
+	}
+
+	response := &dto.AuditEventListResponse{
+		Events:     eventResponses,
+		NextCursor: nextCursor,
+	}
+
+	return utils.SuccessResponse(c, "Audit events retrieved successfully", response)
+}
+
+// ListUserEvents returns events performed by or on the given user
+func (h *AuditHandler) ListUserEvents(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var query dto.SecurityActivityQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	events, err := h.auditService.RecentActivity(c.UserContext(), userID, query.Limit)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve audit events", err)
+	}
+
+	eventResponses := make([]dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		eventResponses[i] = *dto.AuditEventToResponse(event)
+	}
+
+	return utils.SuccessResponse(c, "Audit events retrieved successfully", eventResponses)
+}
+
+// GetSecurityActivity returns the current user's recent security activity
+func (h *AuditHandler) GetSecurityActivity(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var query dto.SecurityActivityQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	events, err := h.auditService.RecentActivity(c.UserContext(), user.ID, query.Limit)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve security activity", err)
+	}
+
+	activity := make([]dto.SecurityActivityResponse, len(events))
+	for i, event := range events {
+		activity[i] = *dto.AuditEventToSecurityActivity(event, user.ID)
+	}
+
+	return utils.SuccessResponse(c, "Security activity retrieved successfully", activity)
+}
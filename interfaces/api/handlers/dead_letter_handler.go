@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DeadLetterHandler struct {
+	deadLetterService services.DeadLetterService
+}
+
+func NewDeadLetterHandler(deadLetterService services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+	}
+}
+
+func (h *DeadLetterHandler) ListEvents(c *fiber.Ctx) error {
+	var query dto.DeadLetterQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	events, meta, err := h.deadLetterService.List(c.UserContext(), &query)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve dead-letter events", err)
+	}
+
+	eventResponses := make([]dto.DeadLetterResponse, len(events))
+	for i, event := range events {
+		eventResponses[i] = *dto.DeadLetterEventToResponse(event, false)
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Dead-letter events retrieved successfully", eventResponses, meta.Total, meta.Offset, meta.Limit)
+}
+
+// GetEvent returns one dead-letter event including its payload
+func (h *DeadLetterHandler) GetEvent(c *fiber.Ctx) error {
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid event ID")
+	}
+
+	event, err := h.deadLetterService.Get(c.UserContext(), eventID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Dead-letter event not found")
+	}
+
+	return utils.SuccessResponse(c, "Dead-letter event retrieved successfully", dto.DeadLetterEventToResponse(event, true))
+}
+
+// RetryEvent queues the event for delivery again; the outbox relay publishes it shortly after
+func (h *DeadLetterHandler) RetryEvent(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid event ID")
+	}
+
+	if err := h.deadLetterService.Retry(c.UserContext(), actor.ID, eventID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Dead-letter event retry failed", err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.Response{
+		Success: true,
+		Message: "Dead-letter event queued for redelivery",
+	})
+}
+
+func (h *DeadLetterHandler) DiscardEvent(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid event ID")
+	}
+
+	if err := h.deadLetterService.Discard(c.UserContext(), actor.ID, eventID); err != nil {
+		return utils.NotFoundResponse(c, "Dead-letter event not found")
+	}
+
+	return utils.SuccessResponse(c, "Dead-letter event discarded successfully", nil)
+}
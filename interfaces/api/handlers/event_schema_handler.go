@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/cloudevents"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EventSchemaHandler publishes the JSON Schemas of event data, generated from the event structs.
+// The dataschema attribute of every event points at GetSchema.
+type EventSchemaHandler struct {
+	subject        string
+	schemaBaseURL  string
+	legacyPayloads bool
+}
+
+func NewEventSchemaHandler(cfg *config.Config) *EventSchemaHandler {
+	return &EventSchemaHandler{
+		subject:        cfg.NATS.Subject,
+		schemaBaseURL:  cfg.Events.SchemaBaseURL,
+		legacyPayloads: cfg.Events.LegacyPayloads,
+	}
+}
+
+// ListSchemas returns every event type with its current and still published data versions
+func (h *EventSchemaHandler) ListSchemas(c *fiber.Ctx) error {
+	schemas := services.EventSchemas()
+
+	responses := make([]dto.EventSchemaResponse, len(schemas))
+	for i, schema := range schemas {
+		response := dto.EventSchemaResponse{
+			Type:             schema.Type,
+			Version:          schema.Version,
+			Subject:          h.subject + "." + services.VersionedTopic(schema.Version, schema.Topic),
+			DataSchema:       services.EventSchemaURL(h.schemaBaseURL, schema.Type, schema.Version),
+			PreviousVersions: make([]dto.EventSchemaVersionResponse, len(schema.Previous)),
+		}
+		for j, previous := range schema.Previous {
+			response.PreviousVersions[j] = dto.EventSchemaVersionResponse{
+				Version:    previous.Version,
+				Subject:    h.subject + "." + services.VersionedTopic(previous.Version, schema.Topic),
+				DataSchema: services.EventSchemaURL(h.schemaBaseURL, schema.Type, previous.Version),
+			}
+		}
+		if h.legacyPayloads {
+			response.LegacySubject = h.subject + "." + schema.Topic
+		}
+		responses[i] = response
+	}
+
+	return utils.SuccessResponse(c, "Event schemas retrieved successfully", responses)
+}
+
+// GetSchema returns the JSON Schema of one data version, e.g. /events/schemas/user.created/v2.
+// The document is served as-is (not wrapped) so it can be used directly by schema validators.
+func (h *EventSchemaHandler) GetSchema(c *fiber.Ctx) error {
+	schema, ok := services.LookupEventSchema(c.Params("type"))
+	if !ok {
+		return utils.NotFoundResponse(c, "Event type not found")
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(c.Params("version"), "v"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid schema version")
+	}
+
+	data, ok := schema.DataForVersion(version)
+	if !ok {
+		return utils.NotFoundResponse(c, "Schema version not found")
+	}
+
+	document := cloudevents.Schema(services.EventSchemaURL(h.schemaBaseURL, schema.Type, version), schema.Type+" data v"+strconv.Itoa(version), data)
+
+	return c.JSON(document, "application/schema+json")
+}
@@ -7,23 +7,61 @@ import (
 
 // Services contains all the services needed for handlers
 type Services struct {
-	UserService  services.UserService
-	OAuthService services.OAuthService
-	Config       *config.Config
+	UserService    services.UserService
+	OAuthService   services.OAuthService
+	TokenService   services.TokenService
+	RBACService    services.RBACService
+	OrgService     services.OrganizationService
+	PATService     services.PersonalAccessTokenService
+	Sessions       services.SessionService
+	AdminService   services.AdminUserService
+	Suspensions    services.SuspensionService
+	Lockouts       services.LockoutService
+	Impersonations services.ImpersonationService
+	AuditService   services.AuditService
+	DeadLetters    services.DeadLetterService
+	Webhooks       services.WebhookService
+	Config         *config.Config
 }
 
 // Handlers contains all HTTP handlers
 type Handlers struct {
-	UserHandler    *UserHandler
-	OAuthHandler   *OAuthHandler
-	MetricsHandler *MetricsHandler
+	UserHandler                *UserHandler
+	OAuthHandler               *OAuthHandler
+	TokenHandler               *TokenHandler
+	RoleHandler                *RoleHandler
+	AdminUserHandler           *AdminUserHandler
+	SuspensionHandler          *SuspensionHandler
+	LockoutHandler             *LockoutHandler
+	ImpersonationHandler       *ImpersonationHandler
+	AuditHandler               *AuditHandler
+	DeadLetterHandler          *DeadLetterHandler
+	WebhookHandler             *WebhookHandler
+	EventSchemaHandler         *EventSchemaHandler
+	OrganizationHandler        *OrganizationHandler
+	PersonalAccessTokenHandler *PersonalAccessTokenHandler
+	SessionHandler             *SessionHandler
+	MetricsHandler             *MetricsHandler
 }
 
 // NewHandlers creates a new instance of Handlers with all dependencies
 func NewHandlers(services *Services) *Handlers {
 	return &Handlers{
-		UserHandler:    NewUserHandler(services.UserService),
-		OAuthHandler:   NewOAuthHandler(services.OAuthService, services.Config),
-		MetricsHandler: NewMetricsHandler(),
+		UserHandler:                NewUserHandler(services.UserService),
+		OAuthHandler:               NewOAuthHandler(services.OAuthService, services.Config),
+		TokenHandler:               NewTokenHandler(services.TokenService),
+		RoleHandler:                NewRoleHandler(services.RBACService),
+		AdminUserHandler:           NewAdminUserHandler(services.AdminService),
+		SuspensionHandler:          NewSuspensionHandler(services.Suspensions),
+		LockoutHandler:             NewLockoutHandler(services.Lockouts),
+		ImpersonationHandler:       NewImpersonationHandler(services.Impersonations),
+		AuditHandler:               NewAuditHandler(services.AuditService),
+		DeadLetterHandler:          NewDeadLetterHandler(services.DeadLetters),
+		WebhookHandler:             NewWebhookHandler(services.Webhooks),
+		EventSchemaHandler:         NewEventSchemaHandler(services.Config),
+		OrganizationHandler:        NewOrganizationHandler(services.OrgService),
+		PersonalAccessTokenHandler: NewPersonalAccessTokenHandler(services.PATService),
+		SessionHandler:             NewSessionHandler(services.Sessions),
+		MetricsHandler:             NewMetricsHandler(),
 	}
-}
\ No newline at end of file
+}
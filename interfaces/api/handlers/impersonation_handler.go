@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ImpersonationHandler struct {
+	impersonationService services.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService services.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+func (h *ImpersonationHandler) StartImpersonation(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	targetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.StartImpersonationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	response, err := h.impersonationService.Start(c.UserContext(), actor.ID, targetID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Impersonation failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Impersonation started",
+		Data:    response,
+	})
+}
+
+func (h *ImpersonationHandler) ListImpersonations(c *fiber.Ctx) error {
+	targetID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	sessions, err := h.impersonationService.ListForUser(c.UserContext(), targetID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve impersonation sessions", err)
+	}
+
+	sessionResponses := make([]dto.ImpersonationSessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = *dto.ImpersonationSessionToResponse(session)
+	}
+
+	return utils.SuccessResponse(c, "Impersonation sessions retrieved successfully", sessionResponses)
+}
+
+// TerminateImpersonation lets an administrator end someone else's impersonation session
+func (h *ImpersonationHandler) TerminateImpersonation(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid session ID")
+	}
+
+	if err := h.impersonationService.End(c.UserContext(), sessionID, user.ID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to end impersonation", err)
+	}
+
+	return utils.SuccessResponse(c, "Impersonation ended", nil)
+}
+
+// EndImpersonation is called with the impersonation token itself
+func (h *ImpersonationHandler) EndImpersonation(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	if !user.IsImpersonated() {
+		return utils.ValidationErrorResponse(c, "Not an impersonation token")
+	}
+
+	sessionID, err := uuid.Parse(user.TokenID)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "Invalid token")
+	}
+
+	if err := h.impersonationService.End(c.UserContext(), sessionID, user.ActorID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to end impersonation", err)
+	}
+
+	return utils.SuccessResponse(c, "Impersonation ended", nil)
+}
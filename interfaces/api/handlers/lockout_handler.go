@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type LockoutHandler struct {
+	lockoutService services.LockoutService
+}
+
+func NewLockoutHandler(lockoutService services.LockoutService) *LockoutHandler {
+	return &LockoutHandler{
+		lockoutService: lockoutService,
+	}
+}
+
+func (h *LockoutHandler) GetLockoutStatus(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	status, err := h.lockoutService.GetStatus(c.UserContext(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusNotFound, "Lockout status not available", err)
+	}
+
+	return utils.SuccessResponse(c, "Lockout status retrieved successfully", status)
+}
+
+func (h *LockoutHandler) UnlockUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.lockoutService.Unlock(c.UserContext(), actor.ID, userID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User unlock failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User unlocked successfully", nil)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/auth_code_store"
@@ -89,9 +90,9 @@ func (h *OAuthHandler) HandleGoogleCallback(c *fiber.Ctx) error {
 	}
 
 	// Handle OAuth callback
-	user, jwtToken, isNewUser, err := h.oauthService.HandleGoogleCallback(c.Context(), code)
+	user, jwtToken, isNewUser, err := h.oauthService.HandleGoogleCallback(c.UserContext(), code)
 	if err != nil {
-		return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=oauth_failed")
+		return h.callbackErrorRedirect(c, err, state)
 	}
 
 	// Generate temporary authorization code
@@ -201,9 +202,9 @@ func (h *OAuthHandler) HandleFacebookCallback(c *fiber.Ctx) error {
 		c.ClearCookie("oauth_state")
 	}
 
-	user, jwtToken, isNewUser, err := h.oauthService.HandleFacebookCallback(c.Context(), code)
+	user, jwtToken, isNewUser, err := h.oauthService.HandleFacebookCallback(c.UserContext(), code)
 	if err != nil {
-		return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=oauth_failed")
+		return h.callbackErrorRedirect(c, err, state)
 	}
 
 	store := auth_code_store.GetInstance()
@@ -274,9 +275,9 @@ func (h *OAuthHandler) HandleLINECallback(c *fiber.Ctx) error {
 		c.ClearCookie("oauth_state")
 	}
 
-	user, jwtToken, isNewUser, err := h.oauthService.HandleLINECallback(c.Context(), code)
+	user, jwtToken, isNewUser, err := h.oauthService.HandleLINECallback(c.UserContext(), code)
 	if err != nil {
-		return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=oauth_failed")
+		return h.callbackErrorRedirect(c, err, state)
 	}
 
 	store := auth_code_store.GetInstance()
@@ -287,3 +288,37 @@ func (h *OAuthHandler) HandleLINECallback(c *fiber.Ctx) error {
 
 	return c.Redirect(h.config.App.FrontendURL + "/auth/callback?code=" + authCode + "&state=" + state)
 }
+
+// callbackErrorRedirect sends the browser back to the frontend after a failed OAuth sign-in.
+// Risky sign-ins carry the challenge to confirm with POST /auth/login/verify.
+func (h *OAuthHandler) callbackErrorRedirect(c *fiber.Ctx, err error, state string) error {
+	var stepUp *services.StepUpRequiredError
+	if errors.As(err, &stepUp) {
+		return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=verification_required&challenge=" + stepUp.ChallengeID + "&state=" + state)
+	}
+	if errors.Is(err, services.ErrLoginDenied) {
+		return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=login_denied")
+	}
+	return c.Redirect(h.config.App.FrontendURL + "/auth/callback?error=oauth_failed")
+}
+
+// UnlinkProvider removes a linked OAuth provider from the current user's account
+func (h *OAuthHandler) UnlinkProvider(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	provider := c.Params("provider")
+	switch provider {
+	case "google", "facebook", "line":
+	default:
+		return utils.ValidationErrorResponse(c, "Invalid provider")
+	}
+
+	if err := h.oauthService.UnlinkProvider(c.UserContext(), user.ID, provider); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to unlink provider", err)
+	}
+
+	return utils.SuccessResponse(c, "Provider unlinked successfully", nil)
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type OrganizationHandler struct {
+	orgService services.OrganizationService
+}
+
+func NewOrganizationHandler(orgService services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.CreateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	org, err := h.orgService.CreateOrganization(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Organization creation failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Organization created successfully",
+		Data:    dto.OrganizationToOrganizationResponse(org, "owner"),
+	})
+}
+
+func (h *OrganizationHandler) ListMyOrganizations(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	memberships, err := h.orgService.ListMyOrganizations(c.UserContext(), user.ID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve organizations", err)
+	}
+
+	orgResponses := make([]dto.OrganizationResponse, len(memberships))
+	for i, membership := range memberships {
+		orgResponses[i] = *dto.OrganizationToOrganizationResponse(&membership.Organization, membership.Role)
+	}
+
+	return utils.SuccessResponse(c, "Organizations retrieved successfully", orgResponses)
+}
+
+func (h *OrganizationHandler) GetOrganization(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	membership, err := h.orgService.GetOrganization(c.UserContext(), user.ID, orgID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Organization not found")
+	}
+
+	return utils.SuccessResponse(c, "Organization retrieved successfully", dto.OrganizationToOrganizationResponse(&membership.Organization, membership.Role))
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	var req dto.UpdateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	org, err := h.orgService.UpdateOrganization(c.UserContext(), user.ID, orgID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Organization update failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Organization updated successfully", dto.OrganizationToOrganizationResponse(org, ""))
+}
+
+func (h *OrganizationHandler) DeleteOrganization(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	if err := h.orgService.DeleteOrganization(c.UserContext(), user.ID, orgID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Organization deletion failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Organization deleted successfully", nil)
+}
+
+func (h *OrganizationHandler) ListMembers(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	members, err := h.orgService.ListMembers(c.UserContext(), user.ID, orgID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Organization not found")
+	}
+
+	memberResponses := make([]dto.OrganizationMemberResponse, len(members))
+	for i, member := range members {
+		memberResponses[i] = *dto.MemberToOrganizationMemberResponse(member)
+	}
+
+	return utils.SuccessResponse(c, "Members retrieved successfully", memberResponses)
+}
+
+func (h *OrganizationHandler) UpdateMemberRole(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.UpdateMemberRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.orgService.UpdateMemberRole(c.UserContext(), user.ID, orgID, memberID, req.Role); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Member role update failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Member role updated successfully", nil)
+}
+
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.orgService.RemoveMember(c.UserContext(), user.ID, orgID, memberID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Member removal failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Member removed successfully", nil)
+}
+
+func (h *OrganizationHandler) CreateInvitation(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	invitation, err := h.orgService.CreateInvitation(c.UserContext(), user.ID, orgID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invitation creation failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Invitation sent successfully",
+		Data:    dto.InvitationToInvitationResponse(invitation),
+	})
+}
+
+func (h *OrganizationHandler) ListInvitations(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	invitations, err := h.orgService.ListInvitations(c.UserContext(), user.ID, orgID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve invitations", err)
+	}
+
+	invitationResponses := make([]dto.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		invitationResponses[i] = *dto.InvitationToInvitationResponse(invitation)
+	}
+
+	return utils.SuccessResponse(c, "Invitations retrieved successfully", invitationResponses)
+}
+
+func (h *OrganizationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	invitationID, err := uuid.Parse(c.Params("invitationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid invitation ID")
+	}
+
+	if err := h.orgService.RevokeInvitation(c.UserContext(), user.ID, orgID, invitationID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invitation revocation failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Invitation revoked successfully", nil)
+}
+
+func (h *OrganizationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.AcceptInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	member, err := h.orgService.AcceptInvitation(c.UserContext(), user.ID, req.Token)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invitation acceptance failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Invitation accepted successfully", dto.OrganizationToOrganizationResponse(&member.Organization, member.Role))
+}
+
+func (h *OrganizationHandler) SwitchOrganization(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid organization ID")
+	}
+
+	token, membership, err := h.orgService.SwitchOrganization(c.UserContext(), user.ID, orgID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Organization switch failed", err)
+	}
+
+	switchResponse := &dto.SwitchOrganizationResponse{
+		Token:          token,
+		OrganizationID: membership.OrganizationID,
+		Role:           membership.Role,
+	}
+	return utils.SuccessResponse(c, "Organization switched successfully", switchResponse)
+}
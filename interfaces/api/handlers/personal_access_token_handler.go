@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PersonalAccessTokenHandler struct {
+	patService services.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(patService services.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		patService: patService,
+	}
+}
+
+func (h *PersonalAccessTokenHandler) CreateToken(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.CreatePersonalAccessTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	token, plaintext, err := h.patService.CreateToken(c.UserContext(), user.ID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Token creation failed", err)
+	}
+
+	c.Set("Cache-Control", "no-store")
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Token created successfully. Copy it now, it will not be shown again",
+		Data: &dto.CreatePersonalAccessTokenResponse{
+			Token:                       plaintext,
+			PersonalAccessTokenResponse: *dto.PersonalAccessTokenToResponse(token),
+		},
+	})
+}
+
+func (h *PersonalAccessTokenHandler) ListTokens(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	tokens, err := h.patService.ListTokens(c.UserContext(), user.ID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve tokens", err)
+	}
+
+	tokenResponses := make([]dto.PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		tokenResponses[i] = *dto.PersonalAccessTokenToResponse(token)
+	}
+
+	return utils.SuccessResponse(c, "Tokens retrieved successfully", tokenResponses)
+}
+
+func (h *PersonalAccessTokenHandler) RevokeToken(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid token ID")
+	}
+
+	if err := h.patService.RevokeToken(c.UserContext(), user.ID, tokenID); err != nil {
+		return utils.NotFoundResponse(c, "Token not found")
+	}
+
+	return utils.SuccessResponse(c, "Token revoked successfully", nil)
+}
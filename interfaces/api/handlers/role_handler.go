@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RoleHandler struct {
+	rbacService services.RBACService
+}
+
+func NewRoleHandler(rbacService services.RBACService) *RoleHandler {
+	return &RoleHandler{
+		rbacService: rbacService,
+	}
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.rbacService.ListRoles(c.UserContext())
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve roles", err)
+	}
+
+	roleResponses := make([]dto.RoleResponse, len(roles))
+	for i, role := range roles {
+		roleResponses[i] = *dto.RoleToRoleResponse(role)
+	}
+
+	return utils.SuccessResponse(c, "Roles retrieved successfully", roleResponses)
+}
+
+func (h *RoleHandler) GetRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid role ID")
+	}
+
+	role, err := h.rbacService.GetRole(c.UserContext(), roleID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Role not found")
+	}
+
+	return utils.SuccessResponse(c, "Role retrieved successfully", dto.RoleToRoleResponse(role))
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	admin, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.CreateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	role, err := h.rbacService.CreateRole(c.UserContext(), &req, admin.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role creation failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Role created successfully",
+		Data:    dto.RoleToRoleResponse(role),
+	})
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	admin, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid role ID")
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	role, err := h.rbacService.UpdateRole(c.UserContext(), roleID, &req, admin.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role update failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Role updated successfully", dto.RoleToRoleResponse(role))
+}
+
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	roleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid role ID")
+	}
+
+	if err := h.rbacService.DeleteRole(c.UserContext(), roleID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role deletion failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Role deleted successfully", nil)
+}
+
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	permissions, err := h.rbacService.ListPermissions(c.UserContext())
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve permissions", err)
+	}
+
+	permissionResponses := make([]dto.PermissionResponse, len(permissions))
+	for i, permission := range permissions {
+		permissionResponses[i] = *dto.PermissionToPermissionResponse(permission)
+	}
+
+	return utils.SuccessResponse(c, "Permissions retrieved successfully", permissionResponses)
+}
+
+func (h *RoleHandler) GetUserRoles(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	userRoles, err := h.rbacService.GetUserRoles(c.UserContext(), userID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "User not found")
+	}
+
+	return utils.SuccessResponse(c, "User roles retrieved successfully", userRoles)
+}
+
+func (h *RoleHandler) AssignUserRole(c *fiber.Ctx) error {
+	admin, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.rbacService.AssignRole(c.UserContext(), userID, req.Role, admin.ID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role assignment failed", err)
+	}
+
+	userRoles, err := h.rbacService.GetUserRoles(c.UserContext(), userID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve user roles", err)
+	}
+
+	return utils.SuccessResponse(c, "Role assigned successfully", userRoles)
+}
+
+func (h *RoleHandler) RemoveUserRole(c *fiber.Ctx) error {
+	admin, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	roleID, err := uuid.Parse(c.Params("roleId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid role ID")
+	}
+
+	if err := h.rbacService.RemoveRole(c.UserContext(), userID, roleID, admin.ID); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role removal failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Role removed successfully", nil)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SessionHandler struct {
+	sessionService services.SessionService
+}
+
+func NewSessionHandler(sessionService services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	sessions, err := h.sessionService.ListForUser(c.UserContext(), user.ID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve sessions", err)
+	}
+
+	sessionResponses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = *dto.UserSessionToResponse(session, user.SessionID)
+	}
+
+	return utils.SuccessResponse(c, "Sessions retrieved successfully", sessionResponses)
+}
+
+// RevokeSession signs the user out of one device. Revoking the current session signs out the caller.
+func (h *SessionHandler) RevokeSession(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid session ID")
+	}
+
+	if err := h.sessionService.Revoke(c.UserContext(), user.ID, sessionID, models.SessionRevokedByUser); err != nil {
+		return utils.NotFoundResponse(c, "Session not found")
+	}
+
+	return utils.SuccessResponse(c, "Session revoked successfully", nil)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type SuspensionHandler struct {
+	suspensionService services.SuspensionService
+}
+
+func NewSuspensionHandler(suspensionService services.SuspensionService) *SuspensionHandler {
+	return &SuspensionHandler{
+		suspensionService: suspensionService,
+	}
+}
+
+func (h *SuspensionHandler) ListSuspensions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	suspensions, err := h.suspensionService.ListForUser(c.UserContext(), userID)
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve suspensions", err)
+	}
+
+	suspensionResponses := make([]dto.SuspensionResponse, len(suspensions))
+	for i, suspension := range suspensions {
+		suspensionResponses[i] = *dto.SuspensionToSuspensionResponse(suspension)
+	}
+
+	return utils.SuccessResponse(c, "Suspensions retrieved successfully", suspensionResponses)
+}
+
+func (h *SuspensionHandler) SuspendUser(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.SuspendUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	suspension, err := h.suspensionService.Suspend(c.UserContext(), actor.ID, userID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User suspension failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "User suspended successfully",
+		Data:    dto.SuspensionToSuspensionResponse(suspension),
+	})
+}
+
+func (h *SuspensionHandler) LiftSuspension(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	// The body is optional
+	var req dto.LiftSuspensionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.suspensionService.Lift(c.UserContext(), actor.ID, userID, req.Reason); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Suspension lift failed", err)
+	}
+
+	return utils.SuccessResponse(c, "User reinstated successfully", nil)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TokenHandler struct {
+	tokenService services.TokenService
+}
+
+func NewTokenHandler(tokenService services.TokenService) *TokenHandler {
+	return &TokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+// Introspect godoc
+// @Summary      Token introspection (RFC 7662)
+// @Description  Report whether an access token or personal access token is active. Requires client credentials.
+// @Tags         OAuth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token            formData  string  true   "Token to introspect"
+// @Param        token_type_hint  formData  string  false  "access_token (other hints are ignored)"
+// @Success      200  {object}  dto.IntrospectionResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Router       /oauth/introspect [post]
+func (h *TokenHandler) Introspect(c *fiber.Ctx) error {
+	var req dto.IntrospectionRequest
+	if err := c.BodyParser(&req); err != nil || utils.ValidateStruct(&req) != nil {
+		return oauthErrorResponse(c, fiber.StatusBadRequest, "invalid_request", "token parameter is required")
+	}
+
+	result, err := h.tokenService.Introspect(c.UserContext(), req.Token, req.TokenTypeHint)
+	if err != nil {
+		return oauthErrorResponse(c, fiber.StatusInternalServerError, "server_error", "introspection failed")
+	}
+
+	if result.Active {
+		if clientID, ok := c.Locals("clientID").(string); ok {
+			result.ClientID = clientID
+		}
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// Revoke godoc
+// @Summary      Token revocation (RFC 7009)
+// @Description  Revoke an access token or personal access token. Unknown or invalid tokens are accepted. Requires client credentials.
+// @Tags         OAuth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        token            formData  string  true   "Token to revoke"
+// @Param        token_type_hint  formData  string  false  "access_token (other hints are ignored)"
+// @Success      200
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /oauth/revoke [post]
+func (h *TokenHandler) Revoke(c *fiber.Ctx) error {
+	var req dto.RevocationRequest
+	if err := c.BodyParser(&req); err != nil || utils.ValidateStruct(&req) != nil {
+		return oauthErrorResponse(c, fiber.StatusBadRequest, "invalid_request", "token parameter is required")
+	}
+
+	if err := h.tokenService.Revoke(c.UserContext(), req.Token, req.TokenTypeHint); err != nil {
+		// The client may retry later (RFC 7009 section 2.2.1)
+		return oauthErrorResponse(c, fiber.StatusServiceUnavailable, "temporarily_unavailable", "revocation state is unavailable")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// oauthErrorResponse writes an RFC 6749 style error body
+func oauthErrorResponse(c *fiber.Ctx, status int, code, description string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"error":             code,
+		"error_description": description,
+	})
+}
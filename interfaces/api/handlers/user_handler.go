@@ -1,11 +1,15 @@
 package handlers
 
 import (
-	"strconv"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/services"
+	"gofiber-template/pkg/password"
 	"gofiber-template/pkg/utils"
+	"math"
+	"strconv"
 )
 
 type UserHandler struct {
@@ -33,8 +37,12 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.userService.Register(c.Context(), &req)
+	user, err := h.userService.Register(c.UserContext(), &req)
 	if err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return passwordPolicyResponse(c, "password", policyErr)
+		}
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Registration failed", err)
 	}
 
@@ -57,16 +65,178 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	token, user, err := h.userService.Login(c.Context(), &req)
+	token, user, err := h.userService.Login(c.UserContext(), &req)
 	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Login failed", err)
+		}
+		var stepUp *services.StepUpRequiredError
+		if errors.As(err, &stepUp) {
+			return c.Status(fiber.StatusUnauthorized).JSON(utils.Response{
+				Success: false,
+				Message: "Verification required",
+				Error:   err.Error(),
+				Data: &dto.LoginChallengeResponse{
+					ChallengeID: stepUp.ChallengeID,
+					Method:      stepUp.Method,
+					ExpiresIn:   int64(stepUp.ExpiresIn.Seconds()),
+				},
+			})
+		}
+		if errors.Is(err, services.ErrLoginDenied) {
+			return utils.ErrorResponse(c, fiber.StatusForbidden, "Login failed", err)
+		}
 		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Login failed", err)
 	}
 
-	loginResponse := &dto.LoginResponse{
+	return utils.SuccessResponse(c, "Login successful", loginResponseFor(token, user))
+}
+
+// VerifyLogin completes a sign-in that required a one-time code
+func (h *UserHandler) VerifyLogin(c *fiber.Ctx) error {
+	var req dto.VerifyLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	token, user, err := h.userService.VerifyLogin(c.UserContext(), &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Verification failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Login successful", loginResponseFor(token, user))
+}
+
+func loginResponseFor(token string, user *models.User) *dto.LoginResponse {
+	return &dto.LoginResponse{
 		Token: token,
 		User:  *dto.UserToUserResponse(user),
 	}
-	return utils.SuccessResponse(c, "Login successful", loginResponse)
+}
+
+func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.userService.RequestPasswordReset(c.UserContext(), req.Email); err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to send password reset email", err)
+	}
+
+	// Same response whether or not the email is registered
+	return utils.SuccessResponse(c, "If the email is registered, a password reset link has been sent", nil)
+}
+
+func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.userService.ResetPassword(c.UserContext(), &req); err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return passwordPolicyResponse(c, "newPassword", policyErr)
+		}
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Password reset failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Password reset successfully", nil)
+}
+
+// ReportLogin handles the "this wasn't me" link of a new sign-in email
+func (h *UserHandler) ReportLogin(c *fiber.Ctx) error {
+	var req dto.ReportLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.userService.ReportUnrecognizedLogin(c.UserContext(), req.Token); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Report failed", err)
+	}
+
+	return utils.SuccessResponse(c, "You have been signed out everywhere. Check your email to set a new password", nil)
+}
+
+func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.userService.ChangePassword(c.UserContext(), user.ID, &req); err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return passwordPolicyResponse(c, "newPassword", policyErr)
+		}
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Password change failed", err)
+	}
+
+	return utils.SuccessResponse(c, "Password changed successfully, please sign in again", nil)
+}
+
+// passwordPolicyResponse lists every rule the password broke with a machine-readable reason
+func passwordPolicyResponse(c *fiber.Ctx, field string, policyErr *password.PolicyError) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"success": false,
+		"message": "Password does not meet the policy",
+		"errors": fiber.Map{
+			field: policyErr.Violations,
+		},
+	})
 }
 
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
@@ -75,7 +245,7 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 		return utils.UnauthorizedResponse(c, "User not authenticated")
 	}
 
-	profile, err := h.userService.GetProfile(c.Context(), user.ID)
+	profile, err := h.userService.GetProfile(c.UserContext(), user.ID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found", err)
 	}
@@ -95,7 +265,7 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		return utils.ValidationErrorResponse(c, "Invalid request body")
 	}
 
-	updatedUser, err := h.userService.UpdateProfile(c.Context(), user.ID, &req)
+	updatedUser, err := h.userService.UpdateProfile(c.UserContext(), user.ID, &req)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Profile update failed", err)
 	}
@@ -110,7 +280,7 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		return utils.UnauthorizedResponse(c, "User not authenticated")
 	}
 
-	err = h.userService.DeleteUser(c.Context(), user.ID)
+	err = h.userService.DeleteUser(c.UserContext(), user.ID)
 	if err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User deletion failed", err)
 	}
@@ -119,21 +289,26 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
-	offsetStr := c.Query("offset", "0")
-	limitStr := c.Query("limit", "10")
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
-		return utils.ValidationErrorResponse(c, "Invalid offset parameter")
+	var query dto.UserListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		return utils.ValidationErrorResponse(c, "Invalid limit parameter")
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
 	}
 
-	users, total, err := h.userService.ListUsers(c.Context(), offset, limit)
+	users, meta, err := h.userService.ListUsers(c.UserContext(), &query)
 	if err != nil {
+		var queryErr *services.InvalidUserQueryError
+		if errors.As(err, &queryErr) {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve users", err)
+		}
 		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to retrieve users", err)
 	}
 
@@ -144,12 +319,8 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 
 	response := &dto.UserListResponse{
 		Users: userResponses,
-		Meta: dto.PaginationMeta{
-			Total:  total,
-			Offset: offset,
-			Limit:  limit,
-		},
+		Meta:  *meta,
 	}
 
 	return utils.SuccessResponse(c, "Users retrieved successfully", response)
-}
\ No newline at end of file
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateEndpoint registers a webhook endpoint; the response is the only one carrying the signing secret
+func (h *WebhookHandler) CreateEndpoint(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.UserContext(), actor.ID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Webhook creation failed", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(utils.Response{
+		Success: true,
+		Message: "Webhook created successfully",
+		Data:    dto.WebhookEndpointToResponse(endpoint, true),
+	})
+}
+
+func (h *WebhookHandler) ListEndpoints(c *fiber.Ctx) error {
+	endpoints, err := h.webhookService.ListEndpoints(c.UserContext())
+	if err != nil {
+		return utils.InternalServerErrorResponse(c, "Failed to retrieve webhooks", err)
+	}
+
+	endpointResponses := make([]dto.WebhookResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpointResponses[i] = *dto.WebhookEndpointToResponse(endpoint, false)
+	}
+
+	return utils.SuccessResponse(c, "Webhooks retrieved successfully", endpointResponses)
+}
+
+func (h *WebhookHandler) GetEndpoint(c *fiber.Ctx) error {
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.UserContext(), endpointID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Webhook not found")
+	}
+
+	return utils.SuccessResponse(c, "Webhook retrieved successfully", dto.WebhookEndpointToResponse(endpoint, false))
+}
+
+func (h *WebhookHandler) UpdateEndpoint(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.UserContext(), actor.ID, endpointID, &req)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Webhook update failed", err)
+	}
+
+	// Echo the secret back only when it was just set
+	return utils.SuccessResponse(c, "Webhook updated successfully", dto.WebhookEndpointToResponse(endpoint, req.Secret != nil))
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.UserContext(), actor.ID, endpointID); err != nil {
+		return utils.NotFoundResponse(c, "Webhook not found")
+	}
+
+	return utils.SuccessResponse(c, "Webhook deleted successfully", nil)
+}
+
+// ListDeliveries returns the delivery log of an endpoint, newest first
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	var query dto.WebhookDeliveryQuery
+	if err := c.QueryParser(&query); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := utils.ValidateStruct(&query); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	deliveries, meta, err := h.webhookService.ListDeliveries(c.UserContext(), endpointID, &query)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to retrieve webhook deliveries", err)
+	}
+
+	deliveryResponses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryResponses[i] = *dto.WebhookDeliveryToResponse(delivery, false)
+	}
+
+	return utils.PaginatedSuccessResponse(c, "Webhook deliveries retrieved successfully", deliveryResponses, meta.Total, meta.Offset, meta.Limit)
+}
+
+// GetDelivery returns one delivery including its payload
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid delivery ID")
+	}
+
+	delivery, err := h.webhookService.GetDelivery(c.UserContext(), endpointID, deliveryID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Webhook delivery not found")
+	}
+
+	return utils.SuccessResponse(c, "Webhook delivery retrieved successfully", dto.WebhookDeliveryToResponse(delivery, true))
+}
+
+// Redeliver queues a finished delivery again; the dispatcher sends it shortly after
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	actor, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	endpointID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid webhook ID")
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid delivery ID")
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.UserContext(), actor.ID, endpointID, deliveryID)
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Webhook redelivery failed", err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.Response{
+		Success: true,
+		Message: "Webhook delivery queued",
+		Data:    dto.WebhookDeliveryToResponse(delivery, false),
+	})
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/contextutil"
 	"gofiber-template/pkg/utils"
 	"log"
 	"os"
@@ -8,6 +10,23 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// tokenService validates tokens against server-side revocation state.
+// When it is not initialized, tokens are validated statelessly.
+var tokenService services.TokenService
+
+// InitAuth wires the token service used by Protected and Optional
+func InitAuth(ts services.TokenService) {
+	tokenService = ts
+}
+
+// authenticate validates a bearer token, honouring revocation when available
+func authenticate(c *fiber.Ctx, token, jwtSecret string) (*utils.UserContext, error) {
+	if tokenService != nil {
+		return tokenService.Authenticate(c.UserContext(), token)
+	}
+	return utils.ValidateTokenStringToUUID(token, jwtSecret)
+}
+
 // Protected middleware validates JWT tokens and sets user context
 func Protected() fiber.Handler {
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -28,7 +47,7 @@ func Protected() fiber.Handler {
 		}
 
 		// Validate token and get user context
-		userCtx, err := utils.ValidateTokenStringToUUID(token, jwtSecret)
+		userCtx, err := authenticate(c, token, jwtSecret)
 		if err != nil {
 			log.Printf("❌ Token validation failed: %v", err)
 			switch err {
@@ -36,6 +55,10 @@ func Protected() fiber.Handler {
 				return utils.UnauthorizedResponse(c, "Token has expired")
 			case utils.ErrInvalidToken:
 				return utils.UnauthorizedResponse(c, "Invalid token")
+			case utils.ErrRevokedToken:
+				return utils.UnauthorizedResponse(c, "Token has been revoked")
+			case utils.ErrRevocationUnavailable:
+				return utils.ErrorResponse(c, fiber.StatusServiceUnavailable, "Token validation failed", err)
 			case utils.ErrMissingToken:
 				return utils.UnauthorizedResponse(c, "Missing token")
 			default:
@@ -45,8 +68,10 @@ func Protected() fiber.Handler {
 
 		log.Printf("✅ Token validated for user: %s (%s)", userCtx.Email, userCtx.ID)
 
-		// Set user context in fiber locals
+		// Set user context in fiber locals and the Go context (for auditing in the service layer)
 		c.Locals("user", userCtx)
+		ctx := contextutil.WithActor(c.UserContext(), userCtx.ID, userCtx.ActorID)
+		c.SetUserContext(contextutil.WithSessionID(ctx, userCtx.SessionID))
 
 		return c.Next()
 	}
@@ -60,7 +85,7 @@ func RequireRole(role string) fiber.Handler {
 			return utils.UnauthorizedResponse(c, "User not authenticated")
 		}
 
-		if user.Role != role {
+		if !user.HasRole(role) {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"success": false,
 				"message": "Insufficient permissions",
@@ -72,6 +97,71 @@ func RequireRole(role string) fiber.Handler {
 	}
 }
 
+// RequirePermission middleware checks that the user has all of the given permissions
+func RequirePermission(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := utils.GetUserFromContext(c)
+		if err != nil {
+			return utils.UnauthorizedResponse(c, "User not authenticated")
+		}
+
+		for _, permission := range permissions {
+			if !user.HasPermission(permission) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"success": false,
+					"message": "Insufficient permissions",
+					"error":   "Missing permission: " + permission,
+				})
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// SessionOnly middleware rejects personal access tokens. Use it on routes that manage
+// credentials or the account itself, so a scoped token cannot widen its own access.
+func SessionOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := utils.GetUserFromContext(c)
+		if err != nil {
+			return utils.UnauthorizedResponse(c, "User not authenticated")
+		}
+
+		if user.IsPersonalAccessToken() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "Insufficient permissions",
+				"error":   "Personal access tokens cannot be used for this operation",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// DenyImpersonation middleware rejects impersonation tokens. Use it on sensitive routes
+// (credentials, account deletion, administration) that an administrator acting as
+// another user must not reach.
+func DenyImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := utils.GetUserFromContext(c)
+		if err != nil {
+			return utils.UnauthorizedResponse(c, "User not authenticated")
+		}
+
+		if user.IsImpersonated() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "Insufficient permissions",
+				"error":   "This operation is not allowed while impersonating a user",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
 // AdminOnly middleware ensures only admin users can access
 func AdminOnly() fiber.Handler {
 	return RequireRole("admin")
@@ -106,12 +196,14 @@ func Optional() fiber.Handler {
 		}
 
 		jwtSecret := os.Getenv("JWT_SECRET")
-		userCtx, err := utils.ValidateTokenStringToUUID(token, jwtSecret)
+		userCtx, err := authenticate(c, token, jwtSecret)
 		if err != nil {
 			return c.Next()
 		}
 
 		c.Locals("user", userCtx)
+		ctx := contextutil.WithActor(c.UserContext(), userCtx.ID, userCtx.ActorID)
+		c.SetUserContext(contextutil.WithSessionID(ctx, userCtx.SessionID))
 		return c.Next()
 	}
 }
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"gofiber-template/pkg/config"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClientCredentials authenticates service clients (RFC 6749 section 2.3.1).
+// Clients are configured as OAUTH_CLIENTS="client_id:secret,other_id:secret".
+// Credentials are accepted via HTTP Basic auth or client_id/client_secret form fields.
+func ClientCredentials() fiber.Handler {
+	clients := config.ParseClientCredentials(os.Getenv("OAUTH_CLIENTS"))
+	if len(clients) == 0 {
+		log.Println("⚠️  OAUTH_CLIENTS not configured, client-authenticated endpoints will reject all requests")
+	}
+
+	return func(c *fiber.Ctx) error {
+		clientID, clientSecret, ok := basicAuthCredentials(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			clientID = c.FormValue("client_id")
+			clientSecret = c.FormValue("client_secret")
+		}
+
+		expected, exists := clients[clientID]
+		if clientID == "" || !exists || subtle.ConstantTimeCompare([]byte(expected), []byte(clientSecret)) != 1 {
+			c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="auth-service"`)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":             "invalid_client",
+				"error_description": "client authentication failed",
+			})
+		}
+
+		c.Locals("clientID", clientID)
+		return c.Next()
+	}
+}
+
+// basicAuthCredentials decodes an HTTP Basic Authorization header
+func basicAuthCredentials(header string) (string, string, bool) {
+	if !strings.HasPrefix(header, "Basic ") {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+	if err != nil {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	// Client credentials are form-urlencoded before being base64 encoded (RFC 6749 section 2.3.1)
+	clientID, err := url.QueryUnescape(parts[0])
+	if err != nil {
+		return "", "", false
+	}
+	clientSecret, err := url.QueryUnescape(parts[1])
+	if err != nil {
+		return "", "", false
+	}
+
+	return clientID, clientSecret, true
+}
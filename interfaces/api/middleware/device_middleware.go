@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"gofiber-template/pkg/contextutil"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	deviceCookieName   = "device_id"
+	deviceHeaderName   = "X-Device-ID" // For clients without a cookie jar (mobile apps)
+	deviceCookieMaxAge = 2 * 365 * 24 * time.Hour
+)
+
+// deviceCookieSecure is wired by InitDeviceCookie
+var deviceCookieSecure bool
+
+// InitDeviceCookie sets whether the device cookie is restricted to HTTPS
+func InitDeviceCookie(secure bool) {
+	deviceCookieSecure = secure
+}
+
+// DeviceCookie identifies the browser signing in with a long-lived random cookie, issuing one
+// when missing, and adds it to the Go context for new-device detection
+func DeviceCookie() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		deviceID := c.Cookies(deviceCookieName)
+		if _, err := uuid.Parse(deviceID); err != nil {
+			deviceID = c.Get(deviceHeaderName)
+		}
+		if _, err := uuid.Parse(deviceID); err != nil {
+			deviceID = uuid.New().String()
+		}
+
+		c.Cookie(&fiber.Cookie{
+			Name:     deviceCookieName,
+			Value:    deviceID,
+			HTTPOnly: true,
+			Secure:   deviceCookieSecure,
+			SameSite: "Lax",
+			Path:     "/",
+			MaxAge:   int(deviceCookieMaxAge.Seconds()),
+		})
+
+		c.SetUserContext(contextutil.WithDeviceID(c.UserContext(), deviceID))
+		return c.Next()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gofiber-template/pkg/contextutil"
+	"strings"
 )
 
 // RequestIDMiddleware adds a unique request ID to each request
@@ -21,6 +22,10 @@ func RequestIDMiddleware() fiber.Handler {
 
 		// Store in Go context (for service layer access)
 		ctx := contextutil.WithRequestID(c.Context(), requestID)
+		ctx = contextutil.WithClientInfo(ctx, c.IP(), c.Get(fiber.HeaderUserAgent))
+		country, location := clientLocation(c)
+		ctx = contextutil.WithClientCountry(ctx, country)
+		ctx = contextutil.WithClientLocation(ctx, location)
 		c.SetUserContext(ctx)
 
 		// Add to response headers
@@ -37,3 +42,30 @@ func GetRequestID(c *fiber.Ctx) string {
 	}
 	return ""
 }
+
+// clientLocation reads the country code and approximate location added by an edge proxy or CDN, if any
+func clientLocation(c *fiber.Ctx) (string, string) {
+	country := strings.ToUpper(firstHeader(c, "CF-IPCountry", "CloudFront-Viewer-Country", "X-Country-Code"))
+	if country == "XX" || country == "T1" {
+		// Cloudflare codes for unknown and Tor
+		country = ""
+	}
+	city := firstHeader(c, "CF-IPCity", "CloudFront-Viewer-City", "X-City")
+
+	parts := make([]string, 0, 2)
+	for _, part := range []string{city, country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return country, strings.Join(parts, ", ")
+}
+
+func firstHeader(c *fiber.Ctx, names ...string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(c.Get(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupAdminRoutes(api fiber.Router, h *handlers.Handlers) {
+	admin := api.Group("/admin")
+	// Impersonation tokens never reach the admin API, whatever the impersonated user may hold
+	admin.Use(middleware.Protected(), middleware.DenyImpersonation())
+
+	// User management
+	admin.Get("/users/:id", middleware.RequirePermission(models.PermUsersRead), h.AdminUserHandler.GetUser)
+	admin.Patch("/users/:id", middleware.RequirePermission(models.PermUsersWrite), h.AdminUserHandler.UpdateUser)
+	admin.Delete("/users/:id", middleware.RequirePermission(models.PermUsersDelete), h.AdminUserHandler.DeleteUser)
+	admin.Post("/users/:id/activate", middleware.RequirePermission(models.PermUsersWrite), h.AdminUserHandler.ActivateUser)
+	admin.Post("/users/:id/deactivate", middleware.RequirePermission(models.PermUsersWrite), h.AdminUserHandler.DeactivateUser)
+	admin.Put("/users/:id/role", middleware.RequirePermission(models.PermUsersWrite, models.PermRolesWrite), h.AdminUserHandler.ChangeRole)
+	admin.Post("/users/:id/password-reset", middleware.RequirePermission(models.PermUsersWrite), h.AdminUserHandler.ForcePasswordReset)
+
+	// Suspensions
+	admin.Get("/users/:id/suspensions", middleware.RequirePermission(models.PermUsersRead), h.SuspensionHandler.ListSuspensions)
+	admin.Post("/users/:id/suspensions", middleware.RequirePermission(models.PermUsersSuspend), h.SuspensionHandler.SuspendUser)
+	admin.Delete("/users/:id/suspensions/active", middleware.RequirePermission(models.PermUsersSuspend), h.SuspensionHandler.LiftSuspension)
+
+	// Failed sign-in lockouts
+	admin.Get("/users/:id/lockout", middleware.RequirePermission(models.PermUsersRead), h.LockoutHandler.GetLockoutStatus)
+	admin.Delete("/users/:id/lockout", middleware.RequirePermission(models.PermUsersWrite), h.LockoutHandler.UnlockUser)
+
+	// Impersonation
+	admin.Post("/users/:id/impersonate", middleware.RequirePermission(models.PermUsersImpersonate), middleware.SessionOnly(), h.ImpersonationHandler.StartImpersonation)
+	admin.Get("/users/:id/impersonations", middleware.RequirePermission(models.PermUsersRead), h.ImpersonationHandler.ListImpersonations)
+	admin.Delete("/impersonations/:id", middleware.RequirePermission(models.PermUsersImpersonate), h.ImpersonationHandler.TerminateImpersonation)
+
+	// Audit log
+	admin.Get("/audit-events", middleware.RequirePermission(models.PermAuditRead), h.AuditHandler.ListEvents)
+	admin.Get("/users/:id/audit-events", middleware.RequirePermission(models.PermAuditRead), h.AuditHandler.ListUserEvents)
+
+	// Dead-letter queue (user events that could not be delivered)
+	admin.Get("/dead-letters", middleware.RequirePermission(models.PermEventsRead), h.DeadLetterHandler.ListEvents)
+	admin.Get("/dead-letters/:id", middleware.RequirePermission(models.PermEventsRead), h.DeadLetterHandler.GetEvent)
+	admin.Post("/dead-letters/:id/retry", middleware.RequirePermission(models.PermEventsWrite), h.DeadLetterHandler.RetryEvent)
+	admin.Delete("/dead-letters/:id", middleware.RequirePermission(models.PermEventsWrite), h.DeadLetterHandler.DiscardEvent)
+
+	// Webhook endpoints and their delivery logs
+	admin.Get("/webhooks", middleware.RequirePermission(models.PermWebhooksRead), h.WebhookHandler.ListEndpoints)
+	admin.Post("/webhooks", middleware.RequirePermission(models.PermWebhooksWrite), h.WebhookHandler.CreateEndpoint)
+	admin.Get("/webhooks/:id", middleware.RequirePermission(models.PermWebhooksRead), h.WebhookHandler.GetEndpoint)
+	admin.Patch("/webhooks/:id", middleware.RequirePermission(models.PermWebhooksWrite), h.WebhookHandler.UpdateEndpoint)
+	admin.Delete("/webhooks/:id", middleware.RequirePermission(models.PermWebhooksWrite), h.WebhookHandler.DeleteEndpoint)
+	admin.Get("/webhooks/:id/deliveries", middleware.RequirePermission(models.PermWebhooksRead), h.WebhookHandler.ListDeliveries)
+	admin.Get("/webhooks/:id/deliveries/:deliveryId", middleware.RequirePermission(models.PermWebhooksRead), h.WebhookHandler.GetDelivery)
+	admin.Post("/webhooks/:id/deliveries/:deliveryId/redeliver", middleware.RequirePermission(models.PermWebhooksWrite), h.WebhookHandler.Redeliver)
+
+	// Roles & permissions
+	admin.Get("/roles", middleware.RequirePermission(models.PermRolesRead), h.RoleHandler.ListRoles)
+	admin.Post("/roles", middleware.RequirePermission(models.PermRolesWrite), h.RoleHandler.CreateRole)
+	admin.Get("/roles/:id", middleware.RequirePermission(models.PermRolesRead), h.RoleHandler.GetRole)
+	admin.Put("/roles/:id", middleware.RequirePermission(models.PermRolesWrite), h.RoleHandler.UpdateRole)
+	admin.Delete("/roles/:id", middleware.RequirePermission(models.PermRolesWrite), h.RoleHandler.DeleteRole)
+	admin.Get("/permissions", middleware.RequirePermission(models.PermRolesRead), h.RoleHandler.ListPermissions)
+
+	// Role assignments
+	admin.Get("/users/:id/roles", middleware.RequirePermission(models.PermRolesRead), h.RoleHandler.GetUserRoles)
+	admin.Post("/users/:id/roles", middleware.RequirePermission(models.PermRolesWrite), h.RoleHandler.AssignUserRole)
+	admin.Delete("/users/:id/roles/:roleId", middleware.RequirePermission(models.PermRolesWrite), h.RoleHandler.RemoveUserRole)
+}
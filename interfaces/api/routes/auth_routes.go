@@ -3,27 +3,35 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
 )
 
 func SetupAuthRoutes(api fiber.Router, h *handlers.Handlers) {
 	auth := api.Group("/auth")
 
 	// Standard Auth
-	auth.Post("/register", h.UserHandler.Register)
-	auth.Post("/login", h.UserHandler.Login)
+	auth.Post("/register", middleware.RateLimit("register", middleware.KeyByIP, middleware.KeyByEmail), h.UserHandler.Register)
+	auth.Post("/login", middleware.RateLimit("login", middleware.KeyByIP, middleware.KeyByEmail), middleware.DeviceCookie(), h.UserHandler.Login)
+	auth.Post("/login/verify", middleware.RateLimit("login", middleware.KeyByIP), middleware.DeviceCookie(), h.UserHandler.VerifyLogin)
+	auth.Post("/login/not-me", middleware.RateLimit("login_report", middleware.KeyByIP, middleware.KeyByToken), h.UserHandler.ReportLogin)
+	auth.Post("/password/forgot", middleware.RateLimit("password_forgot", middleware.KeyByIP, middleware.KeyByEmail), h.UserHandler.ForgotPassword)
+	auth.Post("/password/reset", middleware.RateLimit("password_reset", middleware.KeyByIP, middleware.KeyByToken), h.UserHandler.ResetPassword)
+
+	// Impersonation (called with the impersonation token)
+	auth.Post("/impersonation/end", middleware.Protected(), h.ImpersonationHandler.EndImpersonation)
 
 	// OAuth Code Exchange
-	auth.Post("/exchange", h.OAuthHandler.ExchangeCodeForToken)
+	auth.Post("/exchange", middleware.RateLimit("exchange", middleware.KeyByIP, middleware.KeyByClientID), h.OAuthHandler.ExchangeCodeForToken)
 
 	// Google OAuth
 	auth.Get("/google", h.OAuthHandler.GetGoogleAuthURL)
-	auth.Get("/google/callback", h.OAuthHandler.HandleGoogleCallback)
+	auth.Get("/google/callback", middleware.DeviceCookie(), h.OAuthHandler.HandleGoogleCallback)
 
 	// Facebook OAuth
 	auth.Get("/facebook", h.OAuthHandler.GetFacebookAuthURL)
-	auth.Get("/facebook/callback", h.OAuthHandler.HandleFacebookCallback)
+	auth.Get("/facebook/callback", middleware.DeviceCookie(), h.OAuthHandler.HandleFacebookCallback)
 
 	// LINE OAuth
 	auth.Get("/line", h.OAuthHandler.GetLINEAuthURL)
-	auth.Get("/line/callback", h.OAuthHandler.HandleLINECallback)
+	auth.Get("/line/callback", middleware.DeviceCookie(), h.OAuthHandler.HandleLINECallback)
 }
\ No newline at end of file
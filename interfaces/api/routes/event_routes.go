@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+)
+
+// SetupEventRoutes exposes the event data schemas; they are public so consumers can validate events
+func SetupEventRoutes(api fiber.Router, h *handlers.Handlers) {
+	schemas := api.Group("/events/schemas")
+
+	schemas.Get("/", h.EventSchemaHandler.ListSchemas)
+	schemas.Get("/:type/:version", h.EventSchemaHandler.GetSchema)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+// SetupOAuthRoutes registers service-to-service token endpoints (RFC 7662 / RFC 7009)
+func SetupOAuthRoutes(api fiber.Router, h *handlers.Handlers) {
+	oauth := api.Group("/oauth")
+	oauth.Use(middleware.ClientCredentials())
+	oauth.Post("/introspect", h.TokenHandler.Introspect)
+	oauth.Post("/revoke", h.TokenHandler.Revoke)
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupOrganizationRoutes(api fiber.Router, h *handlers.Handlers) {
+	orgs := api.Group("/organizations")
+	orgs.Use(middleware.Protected(), middleware.SessionOnly())
+
+	orgs.Post("/", h.OrganizationHandler.CreateOrganization)
+	orgs.Get("/", h.OrganizationHandler.ListMyOrganizations)
+
+	// Registered before /:id so "invitations" is not parsed as an organization ID
+	orgs.Post("/invitations/accept", h.OrganizationHandler.AcceptInvitation)
+
+	orgs.Get("/:id", h.OrganizationHandler.GetOrganization)
+	orgs.Patch("/:id", h.OrganizationHandler.UpdateOrganization)
+	orgs.Delete("/:id", middleware.DenyImpersonation(), h.OrganizationHandler.DeleteOrganization)
+	orgs.Post("/:id/switch", middleware.DenyImpersonation(), h.OrganizationHandler.SwitchOrganization)
+
+	// Members
+	orgs.Get("/:id/members", h.OrganizationHandler.ListMembers)
+	orgs.Patch("/:id/members/:userId", h.OrganizationHandler.UpdateMemberRole)
+	orgs.Delete("/:id/members/:userId", h.OrganizationHandler.RemoveMember)
+
+	// Invitations
+	orgs.Post("/:id/invitations", h.OrganizationHandler.CreateInvitation)
+	orgs.Get("/:id/invitations", h.OrganizationHandler.ListInvitations)
+	orgs.Delete("/:id/invitations/:invitationId", h.OrganizationHandler.RevokeInvitation)
+}
@@ -18,4 +18,8 @@ func SetupRoutes(app *fiber.App, h *handlers.Handlers) {
 	// Setup all route groups
 	SetupAuthRoutes(api, h)
 	SetupUserRoutes(api, h)
+	SetupOAuthRoutes(api, h)
+	SetupAdminRoutes(api, h)
+	SetupOrganizationRoutes(api, h)
+	SetupEventRoutes(api, h)
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 )
@@ -10,7 +11,21 @@ func SetupUserRoutes(api fiber.Router, h *handlers.Handlers) {
 	users := api.Group("/users")
 	users.Use(middleware.Protected())
 	users.Get("/profile", h.UserHandler.GetProfile)
-	users.Put("/profile", h.UserHandler.UpdateProfile)
-	users.Delete("/profile", h.UserHandler.DeleteUser)
-	users.Get("/", middleware.AdminOnly(), h.UserHandler.ListUsers)
+	users.Get("/security-activity", middleware.SessionOnly(), h.AuditHandler.GetSecurityActivity)
+	users.Put("/profile", middleware.SessionOnly(), h.UserHandler.UpdateProfile)
+	users.Put("/password", middleware.SessionOnly(), middleware.DenyImpersonation(), h.UserHandler.ChangePassword)
+	users.Delete("/profile", middleware.SessionOnly(), middleware.DenyImpersonation(), h.UserHandler.DeleteUser)
+	users.Get("/", middleware.RequirePermission(models.PermUsersRead), h.UserHandler.ListUsers)
+
+	// Personal access tokens (managed with the user's own session token only)
+	users.Get("/tokens", middleware.SessionOnly(), h.PersonalAccessTokenHandler.ListTokens)
+	users.Post("/tokens", middleware.SessionOnly(), middleware.DenyImpersonation(), h.PersonalAccessTokenHandler.CreateToken)
+	users.Delete("/tokens/:id", middleware.SessionOnly(), middleware.DenyImpersonation(), h.PersonalAccessTokenHandler.RevokeToken)
+
+	// Sign-in sessions (devices the user is logged in on)
+	users.Get("/sessions", middleware.SessionOnly(), h.SessionHandler.ListSessions)
+	users.Delete("/sessions/:id", middleware.SessionOnly(), middleware.DenyImpersonation(), h.SessionHandler.RevokeSession)
+
+	// Linked OAuth providers
+	users.Delete("/providers/:provider", middleware.SessionOnly(), middleware.DenyImpersonation(), h.OAuthHandler.UnlinkProvider)
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package cloudevents
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidName = "github.com/google/uuid.UUID"
+)
+
+// Schema returns a JSON Schema (draft 2020-12) describing the JSON encoding of v.
+// Fields without omitempty are required; unknown properties are allowed so that
+// adding fields within a schema version stays compatible.
+func Schema(id, title string, v interface{}) map[string]interface{} {
+	schema := schemaFor(reflect.TypeOf(v))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = id
+	schema["title"] = title
+	return schema
+}
+
+func schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.PkgPath()+"."+t.Name() == uuidName:
+		return map[string]interface{}{"type": "string", "format": "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
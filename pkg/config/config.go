@@ -3,17 +3,32 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	NATS     NATSConfig
-	JWT      JWTConfig
-	OAuth    OAuthConfig
-	Bunny    BunnyConfig
+	App        AppConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	NATS       NATSConfig
+	JWT        JWTConfig
+	OAuth      OAuthConfig
+	Bunny      BunnyConfig
+	SMTP       SMTPConfig
+	Org        OrganizationConfig
+	Password   PasswordConfig
+	Admin      AdminConfig
+	Audit      AuditConfig
+	RateLimit  RateLimitConfig
+	Lockout    LockoutConfig
+	LoginAlert LoginAlertConfig
+	Risk       RiskConfig
+	Outbox     OutboxConfig
+	Sync       SyncConfig
+	Events     EventsConfig
+	Webhook    WebhookConfig
 }
 
 type AppConfig struct {
@@ -47,10 +62,22 @@ type NATSConfig struct {
 	MaxRetries    int
 	RetryWait     int // seconds
 	EnableJetStream bool
+	MaxPendingAcks  int           // Async publishes awaiting their ack; further publishes wait briefly, then fail
+	AckTimeout      time.Duration // A publish without an ack by then fails and is retried by the caller
+
+	// JetStream stream settings, applied when the publisher starts
+	StreamRetention  string        // "limits" (every consumer reads every event), "interest" or "workqueue" (a single consumer)
+	StreamStorage    string        // "file" or "memory"
+	StreamReplicas   int
+	StreamMaxAge     time.Duration
+	StreamDuplicates time.Duration // Window in which a repeated Nats-Msg-Id is stored once
+	StreamSubjects   []string      // Subjects captured by the stream; must cover every subject events are published on
+	StreamRecreate   bool          // Recreate the stream, if empty, when its retention or storage cannot be changed in place
 }
 
 type JWTConfig struct {
-	Secret string
+	Secret         string
+	AccessTokenTTL time.Duration
 }
 
 type OAuthConfig struct {
@@ -70,6 +97,115 @@ type OAuthConfig struct {
 	LINERedirectURL  string
 }
 
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type OrganizationConfig struct {
+	InvitationTTL time.Duration
+}
+
+type PasswordConfig struct {
+	ResetTokenTTL time.Duration
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	MinScore      int      // Minimum strength score from 0 (any) to 4 (strong)
+	BannedWords   []string // Case-insensitive substrings rejected in any password
+	BreachedDir   string   // Directory of SHA-1 prefix files; empty disables the breached check
+
+	// argon2id cost for new hashes; existing hashes are upgraded on the next successful sign-in
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+type AdminConfig struct {
+	ImpersonationTTL time.Duration // Maximum lifetime of an impersonation token
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Rules   map[string]RateLimitRule // Keyed by route name (login, register, exchange, ...)
+}
+
+// RateLimitRule allows Limit requests per key within a sliding Window
+type RateLimitRule struct {
+	Limit  int
+	Window time.Duration
+}
+
+type LockoutConfig struct {
+	Window             time.Duration // Failed attempts are forgotten this long after the latest one
+	MaxAccountFailures int           // Failures per account before it is locked
+	MaxIPFailures      int           // Failures per IP address before it is locked
+	Duration           time.Duration // How long a lockout lasts
+	BackoffAfter       int           // Failures per account before delays start
+	BackoffBase        time.Duration // First delay, doubled with every further failure
+	BackoffMax         time.Duration
+}
+
+type LoginAlertConfig struct {
+	Enabled bool          // Email and publish an event on sign-ins from a new device or country
+	LinkTTL time.Duration // Validity of the "this wasn't me" link
+}
+
+type RiskConfig struct {
+	Enabled            bool
+	StepUpScore        int           // Total score requiring a one-time code; 0 disables step-up
+	DenyScore          int           // Total score blocking the sign-in; 0 disables denial
+	GeoIPFile          string        // CSV of IP ranges with coordinates for impossible travel; empty disables it
+	TorListFile        string        // Tor exit addresses, one IP or CIDR per line
+	DatacenterListFile string        // Hosting provider ranges, one IP or CIDR per line
+	MaxTravelSpeedKmh  float64       // Faster apparent travel between sign-ins is impossible
+	FailureThreshold   int           // Recent failed attempts (account or IP) that raise the score
+	ChallengeTTL       time.Duration // Validity of the emailed one-time code
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration // How often the relay looks for pending events when idle
+	BatchSize    int           // Events claimed per poll
+	RetryBase    time.Duration // Delay after the first failed publish, doubled with every further failure
+	RetryMax     time.Duration
+	MaxAttempts  int           // Failed publishes before an event moves to the dead-letter queue; 0 retries forever
+	Retention    time.Duration // Published events are deleted after this long
+}
+
+type SyncConfig struct {
+	Workers         int           // Background workers delivering direct user syncs
+	QueueSize       int           // Queued syncs beyond this are dead-lettered immediately
+	ShutdownTimeout time.Duration // How long shutdown waits for queued syncs before cancelling them
+}
+
+type EventsConfig struct {
+	Source         string // CloudEvents source attribute of published events
+	SchemaBaseURL  string // Base of the dataschema URIs; serves GET /api/v1/events/schemas
+	LegacyPayloads bool   // Also publish the pre-CloudEvents flat payloads on the unversioned subjects
+}
+
+type WebhookConfig struct {
+	PollInterval time.Duration // How often the dispatcher looks for due deliveries when idle
+	BatchSize    int           // Deliveries sent concurrently per poll
+	Timeout      time.Duration // Per request; slower endpoints count as failed
+	MaxAttempts  int           // Attempts before a delivery is marked failed
+	RetryBase    time.Duration // Delay after the first failed attempt, doubled with every further failure
+	RetryMax     time.Duration
+	DisableAfter int           // Consecutive failed attempts after which an endpoint is disabled; 0 never disables
+	Retention    time.Duration // Finished deliveries are deleted after this long
+}
+
+type AuditConfig struct {
+	SigningKey         string // Base64 Ed25519 seed used to sign chain checkpoints; empty disables checkpoints
+	CheckpointSchedule string // Cron expression for writing checkpoints
+}
+
 type BunnyConfig struct {
 	StorageZone string
 	AccessKey   string
@@ -86,6 +222,33 @@ func LoadConfig() (*Config, error) {
 	natsMaxRetries, _ := strconv.Atoi(getEnv("NATS_MAX_RETRIES", "3"))
 	natsRetryWait, _ := strconv.Atoi(getEnv("NATS_RETRY_WAIT", "1"))
 	natsEnableJS := getEnv("NATS_ENABLE_JETSTREAM", "true") == "true"
+	natsMaxPendingAcks, _ := strconv.Atoi(getEnv("NATS_MAX_PENDING_ACKS", "256"))
+	natsStreamReplicas, _ := strconv.Atoi(getEnv("NATS_STREAM_REPLICAS", "1"))
+	natsSubject := getEnv("NATS_SUBJECT", "user.events")
+	natsStreamSubjects := getEnvList("NATS_STREAM_SUBJECTS")
+	if len(natsStreamSubjects) == 0 {
+		natsStreamSubjects = []string{natsSubject + ".>"}
+	}
+	passwordMinLength, _ := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	passwordMaxLength, _ := strconv.Atoi(getEnv("PASSWORD_MAX_LENGTH", "128"))
+	passwordMinScore, _ := strconv.Atoi(getEnv("PASSWORD_MIN_SCORE", "2"))
+	argon2Memory, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_MEMORY_KIB", "65536"), 10, 32)
+	argon2Iterations, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_ITERATIONS", "3"), 10, 32)
+	argon2Parallelism, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_PARALLELISM", "2"), 10, 8)
+	lockoutMaxAccount, _ := strconv.Atoi(getEnv("LOCKOUT_MAX_ACCOUNT_FAILURES", "10"))
+	lockoutMaxIP, _ := strconv.Atoi(getEnv("LOCKOUT_MAX_IP_FAILURES", "50"))
+	lockoutBackoffAfter, _ := strconv.Atoi(getEnv("LOCKOUT_BACKOFF_AFTER", "3"))
+	riskStepUpScore, _ := strconv.Atoi(getEnv("RISK_STEP_UP_SCORE", "50"))
+	riskDenyScore, _ := strconv.Atoi(getEnv("RISK_DENY_SCORE", "90"))
+	riskMaxTravelSpeed, _ := strconv.ParseFloat(getEnv("RISK_MAX_TRAVEL_SPEED_KMH", "1000"), 64)
+	riskFailureThreshold, _ := strconv.Atoi(getEnv("RISK_FAILURE_THRESHOLD", "3"))
+	outboxBatchSize, _ := strconv.Atoi(getEnv("OUTBOX_BATCH_SIZE", "100"))
+	outboxMaxAttempts, _ := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "20"))
+	syncWorkers, _ := strconv.Atoi(getEnv("SYNC_WORKERS", "4"))
+	webhookBatchSize, _ := strconv.Atoi(getEnv("WEBHOOK_BATCH_SIZE", "20"))
+	webhookMaxAttempts, _ := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "10"))
+	webhookDisableAfter, _ := strconv.Atoi(getEnv("WEBHOOK_DISABLE_AFTER", "50"))
+	syncQueueSize, _ := strconv.Atoi(getEnv("SYNC_QUEUE_SIZE", "1000"))
 
 	config := &Config{
 		App: AppConfig{
@@ -111,14 +274,25 @@ func LoadConfig() (*Config, error) {
 		NATS: NATSConfig{
 			URL:             getEnv("NATS_URL", "nats://localhost:4222"),
 			StreamName:      getEnv("NATS_STREAM_NAME", "USER_EVENTS"),
-			Subject:         getEnv("NATS_SUBJECT", "user.events"),
+			Subject:         natsSubject,
 			DurableName:     getEnv("NATS_DURABLE_NAME", "auth-service"),
 			MaxRetries:      natsMaxRetries,
 			RetryWait:       natsRetryWait,
 			EnableJetStream: natsEnableJS,
+			MaxPendingAcks:  natsMaxPendingAcks,
+			AckTimeout:      getEnvDuration("NATS_ACK_TIMEOUT", 5*time.Second),
+
+			StreamRetention:  getEnv("NATS_STREAM_RETENTION", "limits"),
+			StreamStorage:    getEnv("NATS_STREAM_STORAGE", "file"),
+			StreamReplicas:   natsStreamReplicas,
+			StreamMaxAge:     getEnvDuration("NATS_STREAM_MAX_AGE", 7*24*time.Hour),
+			StreamDuplicates: getEnvDuration("NATS_STREAM_DUPLICATE_WINDOW", 5*time.Minute),
+			StreamSubjects:   natsStreamSubjects,
+			StreamRecreate:   getEnv("NATS_STREAM_RECREATE", "false") == "true",
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-secret-key"),
+			Secret:         getEnv("JWT_SECRET", "your-secret-key"),
+			AccessTokenTTL: getEnvDuration("JWT_ACCESS_TOKEN_TTL", 7*24*time.Hour),
 		},
 		OAuth: OAuthConfig{
 			GoogleClientID:       getEnv("GOOGLE_CLIENT_ID", ""),
@@ -137,6 +311,102 @@ func LoadConfig() (*Config, error) {
 			BaseURL:     getEnv("BUNNY_BASE_URL", "https://storage.bunnycdn.com"),
 			CDNUrl:      getEnv("BUNNY_CDN_URL", ""),
 		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "no-reply@localhost"),
+		},
+		Org: OrganizationConfig{
+			InvitationTTL: getEnvDuration("ORG_INVITATION_TTL", 7*24*time.Hour),
+		},
+		Password: PasswordConfig{
+			ResetTokenTTL: getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+			MinLength:     passwordMinLength,
+			MaxLength:     passwordMaxLength,
+			RequireUpper:  getEnv("PASSWORD_REQUIRE_UPPER", "false") == "true",
+			RequireLower:  getEnv("PASSWORD_REQUIRE_LOWER", "false") == "true",
+			RequireDigit:  getEnv("PASSWORD_REQUIRE_DIGIT", "false") == "true",
+			RequireSymbol: getEnv("PASSWORD_REQUIRE_SYMBOL", "false") == "true",
+			MinScore:      passwordMinScore,
+			BannedWords:   getEnvList("PASSWORD_BANNED_WORDS"),
+			BreachedDir:   getEnv("PASSWORD_BREACHED_DIR", ""),
+
+			Argon2Memory:      uint32(argon2Memory),
+			Argon2Iterations:  uint32(argon2Iterations),
+			Argon2Parallelism: uint8(argon2Parallelism),
+		},
+		Admin: AdminConfig{
+			ImpersonationTTL: getEnvDuration("IMPERSONATION_TTL", 15*time.Minute),
+		},
+		Audit: AuditConfig{
+			SigningKey:         getEnv("AUDIT_SIGNING_KEY", ""),
+			CheckpointSchedule: getEnv("AUDIT_CHECKPOINT_SCHEDULE", "0 * * * *"),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+			Rules: map[string]RateLimitRule{
+				"login":           getEnvRateLimit("RATE_LIMIT_LOGIN", RateLimitRule{Limit: 5, Window: 15 * time.Minute}),
+				"register":        getEnvRateLimit("RATE_LIMIT_REGISTER", RateLimitRule{Limit: 3, Window: 15 * time.Minute}),
+				"exchange":        getEnvRateLimit("RATE_LIMIT_EXCHANGE", RateLimitRule{Limit: 10, Window: time.Minute}),
+				"login_report":    getEnvRateLimit("RATE_LIMIT_LOGIN_REPORT", RateLimitRule{Limit: 5, Window: 15 * time.Minute}),
+				"password_forgot": getEnvRateLimit("RATE_LIMIT_PASSWORD_FORGOT", RateLimitRule{Limit: 3, Window: 15 * time.Minute}),
+				"password_reset":  getEnvRateLimit("RATE_LIMIT_PASSWORD_RESET", RateLimitRule{Limit: 5, Window: 15 * time.Minute}),
+			},
+		},
+		Lockout: LockoutConfig{
+			Window:             getEnvDuration("LOCKOUT_WINDOW", 15*time.Minute),
+			MaxAccountFailures: lockoutMaxAccount,
+			MaxIPFailures:      lockoutMaxIP,
+			Duration:           getEnvDuration("LOCKOUT_DURATION", 15*time.Minute),
+			BackoffAfter:       lockoutBackoffAfter,
+			BackoffBase:        getEnvDuration("LOCKOUT_BACKOFF_BASE", time.Second),
+			BackoffMax:         getEnvDuration("LOCKOUT_BACKOFF_MAX", 30*time.Second),
+		},
+		LoginAlert: LoginAlertConfig{
+			Enabled: getEnv("LOGIN_ALERT_ENABLED", "true") == "true",
+			LinkTTL: getEnvDuration("LOGIN_ALERT_LINK_TTL", 7*24*time.Hour),
+		},
+		Risk: RiskConfig{
+			Enabled:            getEnv("RISK_ENABLED", "true") == "true",
+			StepUpScore:        riskStepUpScore,
+			DenyScore:          riskDenyScore,
+			GeoIPFile:          getEnv("RISK_GEOIP_FILE", ""),
+			TorListFile:        getEnv("RISK_TOR_LIST_FILE", ""),
+			DatacenterListFile: getEnv("RISK_DATACENTER_LIST_FILE", ""),
+			MaxTravelSpeedKmh:  riskMaxTravelSpeed,
+			FailureThreshold:   riskFailureThreshold,
+			ChallengeTTL:       getEnvDuration("RISK_CHALLENGE_TTL", 10*time.Minute),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:    outboxBatchSize,
+			RetryBase:    getEnvDuration("OUTBOX_RETRY_BASE", time.Second),
+			RetryMax:     getEnvDuration("OUTBOX_RETRY_MAX", 5*time.Minute),
+			MaxAttempts:  outboxMaxAttempts,
+			Retention:    getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		},
+		Sync: SyncConfig{
+			Workers:         syncWorkers,
+			QueueSize:       syncQueueSize,
+			ShutdownTimeout: getEnvDuration("SYNC_SHUTDOWN_TIMEOUT", 30*time.Second),
+		},
+		Events: EventsConfig{
+			Source:         getEnv("EVENT_SOURCE", "/gofiber-auth"),
+			SchemaBaseURL:  getEnv("EVENT_SCHEMA_BASE_URL", "http://localhost:3000/api/v1/events/schemas"),
+			LegacyPayloads: getEnv("EVENT_LEGACY_PAYLOADS", "true") == "true",
+		},
+		Webhook: WebhookConfig{
+			PollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", time.Second),
+			BatchSize:    webhookBatchSize,
+			Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  webhookMaxAttempts,
+			RetryBase:    getEnvDuration("WEBHOOK_RETRY_BASE", 10*time.Second),
+			RetryMax:     getEnvDuration("WEBHOOK_RETRY_MAX", time.Hour),
+			DisableAfter: webhookDisableAfter,
+			Retention:    getEnvDuration("WEBHOOK_RETENTION", 30*24*time.Hour),
+		},
 	}
 
 	return config, nil
@@ -148,4 +418,61 @@ func getEnv(key, defaultValue string) string {
 		return defaultValue
 	}
 	return value
-}
\ No newline at end of file
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvList parses a comma-separated list, dropping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvRateLimit parses a "<limit>/<window>" rule such as "5/15m"
+func getEnvRateLimit(key string, defaultValue RateLimitRule) RateLimitRule {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return defaultValue
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit <= 0 {
+		return defaultValue
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window <= 0 {
+		return defaultValue
+	}
+	return RateLimitRule{Limit: limit, Window: window}
+}
+
+// ParseClientCredentials parses "client_id:secret,client_id2:secret2" into a map
+func ParseClientCredentials(value string) map[string]string {
+	clients := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		clients[parts[0]] = parts[1]
+	}
+	return clients
+}
@@ -1,11 +1,23 @@
 package contextutil
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 type contextKey string
 
 const (
-	requestIDKey contextKey = "request_id"
+	requestIDKey      contextKey = "request_id"
+	clientIPKey       contextKey = "client_ip"
+	userAgentKey      contextKey = "user_agent"
+	locationKey       contextKey = "client_location"
+	countryKey        contextKey = "client_country"
+	deviceIDKey       contextKey = "device_id"
+	sessionIDKey      contextKey = "session_id"
+	actorIDKey        contextKey = "actor_id"
+	impersonatorIDKey contextKey = "impersonator_id"
 )
 
 // WithRequestID adds a request ID to the context
@@ -20,3 +32,100 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// WithClientInfo adds the caller's IP address and user agent to the context
+func WithClientInfo(ctx context.Context, clientIP, userAgent string) context.Context {
+	ctx = context.WithValue(ctx, clientIPKey, clientIP)
+	return context.WithValue(ctx, userAgentKey, userAgent)
+}
+
+// GetClientIP retrieves the caller's IP address from the context
+func GetClientIP(ctx context.Context) string {
+	if clientIP, ok := ctx.Value(clientIPKey).(string); ok {
+		return clientIP
+	}
+	return ""
+}
+
+// GetUserAgent retrieves the caller's user agent from the context
+func GetUserAgent(ctx context.Context) string {
+	if userAgent, ok := ctx.Value(userAgentKey).(string); ok {
+		return userAgent
+	}
+	return ""
+}
+
+// WithClientLocation adds the caller's approximate location (e.g. "TH" or "Bangkok, TH") to the context
+func WithClientLocation(ctx context.Context, location string) context.Context {
+	return context.WithValue(ctx, locationKey, location)
+}
+
+// GetClientLocation retrieves the caller's approximate location from the context
+func GetClientLocation(ctx context.Context) string {
+	if location, ok := ctx.Value(locationKey).(string); ok {
+		return location
+	}
+	return ""
+}
+
+// WithClientCountry adds the caller's ISO 3166 country code to the context
+func WithClientCountry(ctx context.Context, country string) context.Context {
+	return context.WithValue(ctx, countryKey, country)
+}
+
+// GetClientCountry retrieves the caller's country code from the context ("" when unknown)
+func GetClientCountry(ctx context.Context) string {
+	if country, ok := ctx.Value(countryKey).(string); ok {
+		return country
+	}
+	return ""
+}
+
+// WithDeviceID adds the caller's device identifier (from the device cookie) to the context
+func WithDeviceID(ctx context.Context, deviceID string) context.Context {
+	return context.WithValue(ctx, deviceIDKey, deviceID)
+}
+
+// GetDeviceID retrieves the caller's device identifier from the context
+func GetDeviceID(ctx context.Context) string {
+	if deviceID, ok := ctx.Value(deviceIDKey).(string); ok {
+		return deviceID
+	}
+	return ""
+}
+
+// WithActor adds the authenticated user to the context. impersonatorID is uuid.Nil
+// unless an administrator is acting as the user.
+func WithActor(ctx context.Context, actorID, impersonatorID uuid.UUID) context.Context {
+	ctx = context.WithValue(ctx, actorIDKey, actorID)
+	return context.WithValue(ctx, impersonatorIDKey, impersonatorID)
+}
+
+// GetActorID retrieves the authenticated user from the context (uuid.Nil when anonymous)
+func GetActorID(ctx context.Context) uuid.UUID {
+	if actorID, ok := ctx.Value(actorIDKey).(uuid.UUID); ok {
+		return actorID
+	}
+	return uuid.Nil
+}
+
+// GetImpersonatorID retrieves the impersonating administrator from the context (uuid.Nil when none)
+func GetImpersonatorID(ctx context.Context) uuid.UUID {
+	if impersonatorID, ok := ctx.Value(impersonatorIDKey).(uuid.UUID); ok {
+		return impersonatorID
+	}
+	return uuid.Nil
+}
+
+// WithSessionID adds the sign-in session of the presented token to the context
+func WithSessionID(ctx context.Context, sessionID uuid.UUID) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// GetSessionID retrieves the sign-in session from the context (uuid.Nil when the token has none)
+func GetSessionID(ctx context.Context) uuid.UUID {
+	if sessionID, ok := ctx.Value(sessionIDKey).(uuid.UUID); ok {
+		return sessionID
+	}
+	return uuid.Nil
+}
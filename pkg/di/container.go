@@ -6,12 +6,15 @@ import (
 	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/email"
 	"gofiber-template/infrastructure/nats"
 	"gofiber-template/infrastructure/postgres"
 	"gofiber-template/infrastructure/redis"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/ratelimit"
 	"gofiber-template/pkg/scheduler"
+	"gofiber-template/pkg/workerpool"
 	"gorm.io/gorm"
 )
 
@@ -22,17 +25,52 @@ type Container struct {
 	// Infrastructure
 	DB             *gorm.DB
 	RedisClient    *redis.RedisClient
+	RateLimiter    ratelimit.Limiter
 	EventPublisher services.EventPublisher
 	EventScheduler scheduler.EventScheduler
+	EmailSender    services.EmailSender
 
 	// Repositories
-	UserRepository  repositories.UserRepository
-	OAuthRepository repositories.OAuthRepository
+	UserRepository            repositories.UserRepository
+	OAuthRepository           repositories.OAuthRepository
+	TokenRevocationRepository repositories.TokenRevocationRepository
+	RoleRepository            repositories.RoleRepository
+	OrganizationRepository    repositories.OrganizationRepository
+	PATRepository             repositories.PersonalAccessTokenRepository
+	PasswordResetRepository   repositories.PasswordResetRepository
+	SuspensionRepository      repositories.SuspensionRepository
+	ImpersonationRepository   repositories.ImpersonationRepository
+	AuditRepository           repositories.AuditRepository
+	LoginAttemptRepository    repositories.LoginAttemptRepository
+	SessionRepository         repositories.SessionRepository
+	KnownDeviceRepository     repositories.KnownDeviceRepository
+	LoginChallengeRepository  repositories.LoginChallengeRepository
+	OutboxRepository          repositories.OutboxRepository
+	DeadLetterRepository      repositories.DeadLetterRepository
+	WebhookRepository         repositories.WebhookRepository
 
 	// Services
-	SyncService  *serviceimpl.SyncService
-	UserService  services.UserService
-	OAuthService services.OAuthService
+	Webhooks       services.WebhookService
+	Dispatcher     *serviceimpl.WebhookDispatcher
+	Events         *serviceimpl.EventEmitter
+	SyncService    *serviceimpl.SyncService
+	OutboxRelay    *serviceimpl.OutboxRelay
+	SyncWorkers    *workerpool.Pool
+	AuditService   services.AuditService
+	DeadLetters    services.DeadLetterService
+	RBACService    services.RBACService
+	PATService     services.PersonalAccessTokenService
+	Sessions       services.SessionService
+	TokenService   services.TokenService
+	Suspensions    services.SuspensionService
+	Lockouts       services.LockoutService
+	Devices        services.DeviceService
+	Risk           services.RiskService
+	UserService    services.UserService
+	OAuthService   services.OAuthService
+	OrgService     services.OrganizationService
+	AdminService   services.AdminUserService
+	Impersonations services.ImpersonationService
 }
 
 func NewContainer() *Container {
@@ -113,33 +151,153 @@ func (c *Container) initInfrastructure() error {
 		log.Println("✓ Redis connected")
 	}
 
-	// Initialize NATS Event Publisher
+	// Rate limits are shared through Redis and degrade to per-instance counting without it
+	c.RateLimiter = ratelimit.WithFallback(redis.NewRateLimiter(c.RedisClient), ratelimit.NewMemoryLimiter())
+
+	// Initialize NATS Event Publisher; an unreachable server is tolerated, a broken stream configuration is not
+	if c.Config.NATS.EnableJetStream {
+		if err := nats.ValidateStreamConfig(&c.Config.NATS); err != nil {
+			return err
+		}
+	}
 	natsPublisher, err := nats.NewNATSPublisher(&c.Config.NATS)
 	if err != nil {
 		log.Printf("Warning: NATS connection failed: %v", err)
-		log.Println("⚠️  Will use HTTP sync as fallback")
+		log.Println("⚠️  Events will only be delivered to webhooks")
 		c.EventPublisher = nil
 	} else {
 		c.EventPublisher = natsPublisher
 	}
 
+	// Initialize Email Sender (logs messages when SMTP is not configured)
+	c.EmailSender = email.NewEmailSender(&c.Config.SMTP)
+
 	return nil
 }
 
 func (c *Container) initRepositories() error {
 	c.UserRepository = postgres.NewUserRepository(c.DB)
 	c.OAuthRepository = postgres.NewOAuthRepository(c.DB)
+	c.TokenRevocationRepository = redis.NewTokenRevocationRepository(c.RedisClient, c.Config.JWT.AccessTokenTTL)
+	c.RoleRepository = postgres.NewRoleRepository(c.DB)
+	c.OrganizationRepository = postgres.NewOrganizationRepository(c.DB)
+	c.PATRepository = postgres.NewPersonalAccessTokenRepository(c.DB)
+	c.PasswordResetRepository = postgres.NewPasswordResetRepository(c.DB)
+	c.SuspensionRepository = postgres.NewSuspensionRepository(c.DB)
+	c.ImpersonationRepository = postgres.NewImpersonationRepository(c.DB)
+	c.AuditRepository = postgres.NewAuditRepository(c.DB)
+	c.LoginAttemptRepository = redis.NewLoginAttemptRepository(c.RedisClient)
+	c.SessionRepository = postgres.NewSessionRepository(c.DB)
+	c.KnownDeviceRepository = postgres.NewKnownDeviceRepository(c.DB)
+	c.LoginChallengeRepository = redis.NewLoginChallengeRepository(c.RedisClient)
+	c.OutboxRepository = postgres.NewOutboxRepository(c.DB)
+	c.DeadLetterRepository = postgres.NewDeadLetterRepository(c.DB)
+	c.WebhookRepository = postgres.NewWebhookRepository(c.DB)
 	log.Println("✓ Repositories initialized")
 	return nil
 }
 
 func (c *Container) initServices() error {
-	// Initialize SyncService with EventPublisher
-	c.SyncService = serviceimpl.NewSyncServiceWithPublisher(c.EventPublisher)
+	// Initialize AuditService (security audit log, used by the services below)
+	c.AuditService = serviceimpl.NewAuditService(c.AuditRepository, c.Config)
+
+	// Initialize DeadLetterService (events that could not be delivered)
+	c.DeadLetters = serviceimpl.NewDeadLetterService(c.DeadLetterRepository, c.AuditService)
+
+	// Initialize WebhookService (signed event deliveries to subscribed endpoints) and its dispatcher
+	c.Webhooks = serviceimpl.NewWebhookService(c.WebhookRepository, c.AuditService)
+	c.Dispatcher = serviceimpl.NewWebhookDispatcher(c.WebhookRepository, c.AuditService, c.Config)
+
+	// Initialize EventEmitter (CloudEvents envelopes over the EventPublisher and webhooks)
+	c.Events = serviceimpl.NewEventEmitter(c.EventPublisher, c.Webhooks, c.Config)
+
+	// Initialize SyncService with EventEmitter and its background workers
+	c.SyncWorkers = workerpool.New("user-sync", c.Config.Sync.Workers, c.Config.Sync.QueueSize)
+	c.SyncService = serviceimpl.NewSyncServiceWithPublisher(c.Events, c.DeadLetters, c.SyncWorkers)
+
+	// Initialize OutboxRelay (publishes user events stored with the user changes)
+	c.OutboxRelay = serviceimpl.NewOutboxRelay(c.OutboxRepository, c.SyncService, c.DeadLetters, c.Config)
+
+	// Initialize RBACService (roles, permissions and assignments)
+	c.RBACService = serviceimpl.NewRBACService(c.RoleRepository, c.UserRepository, c.TokenRevocationRepository, c.AuditService, c.SyncService)
+
+	// Initialize PersonalAccessTokenService (user-managed API tokens)
+	c.PATService = serviceimpl.NewPersonalAccessTokenService(c.PATRepository, c.UserRepository, c.SuspensionRepository, c.RBACService, c.AuditService)
+
+	// Initialize SessionService (sign-ins per device, referenced by the sid claim)
+	c.Sessions = serviceimpl.NewSessionService(c.SessionRepository, c.TokenRevocationRepository, c.UserRepository, c.AuditService, c.SyncService)
+
+	// Initialize TokenService (JWT issuance, revocation state and personal access tokens)
+	c.TokenService = serviceimpl.NewTokenService(
+		c.UserRepository,
+		c.OrganizationRepository,
+		c.TokenRevocationRepository,
+		c.SuspensionRepository,
+		c.ImpersonationRepository,
+		c.RBACService,
+		c.PATService,
+		c.Sessions,
+		c.AuditService,
+		c.Config.JWT,
+	)
+
+	// Initialize SuspensionService (suspensions, bans and reinstatement)
+	c.Suspensions = serviceimpl.NewSuspensionService(c.SuspensionRepository, c.UserRepository, c.TokenService, c.AuditService, c.SyncService)
+
+	// Initialize LockoutService (progressive delays and lockouts after failed sign-ins)
+	c.Lockouts = serviceimpl.NewLockoutService(c.LoginAttemptRepository, c.UserRepository, c.AuditService, c.EmailSender, c.Config)
+
+	// Initialize DeviceService (known devices and new sign-in alerts)
+	c.Devices = serviceimpl.NewDeviceService(c.KnownDeviceRepository, c.AuditService, c.EmailSender, c.Events, c.Config)
+
+	// Initialize RiskService (sign-in risk scoring and step-up challenges)
+	c.Risk = serviceimpl.NewRiskService(
+		c.UserRepository,
+		c.SessionRepository,
+		c.LoginAttemptRepository,
+		c.LoginChallengeRepository,
+		c.AuditService,
+		c.EmailSender,
+		c.Config,
+	)
 
 	// Initialize UserService and OAuthService with SyncService
-	c.UserService = serviceimpl.NewUserService(c.UserRepository, c.Config.JWT.Secret, c.SyncService)
-	c.OAuthService = serviceimpl.NewOAuthService(c.UserRepository, c.OAuthRepository, c.UserService, c.SyncService, c.Config)
+	c.UserService = serviceimpl.NewUserService(c.UserRepository, c.PasswordResetRepository, c.PATRepository, c.TokenService, c.Suspensions, c.Lockouts, c.Devices, c.Risk, c.AuditService, c.SyncService, c.EmailSender, c.Config)
+	c.OAuthService = serviceimpl.NewOAuthService(c.UserRepository, c.OAuthRepository, c.UserService, c.Suspensions, c.Devices, c.Risk, c.AuditService, c.SyncService, c.Config)
+
+	// Initialize AdminUserService (account management by administrators)
+	c.AdminService = serviceimpl.NewAdminUserService(
+		c.UserRepository,
+		c.RoleRepository,
+		c.PATRepository,
+		c.RBACService,
+		c.TokenService,
+		c.UserService,
+		c.AuditService,
+		c.SyncService,
+	)
+
+	// Initialize ImpersonationService (support staff acting as a user)
+	c.Impersonations = serviceimpl.NewImpersonationService(
+		c.ImpersonationRepository,
+		c.UserRepository,
+		c.SuspensionRepository,
+		c.RBACService,
+		c.TokenService,
+		c.AuditService,
+		c.Config,
+	)
+
+	// Initialize OrganizationService (workspaces, memberships and invitations)
+	c.OrgService = serviceimpl.NewOrganizationService(
+		c.OrganizationRepository,
+		c.UserRepository,
+		c.TokenRevocationRepository,
+		c.TokenService,
+		c.EmailSender,
+		c.SyncService,
+		c.Config,
+	)
 	log.Println("✓ Services initialized")
 	return nil
 }
@@ -147,10 +305,76 @@ func (c *Container) initServices() error {
 func (c *Container) initScheduler() error {
 	c.EventScheduler = scheduler.NewEventScheduler()
 
-	// Start the scheduler (will be used for cleanup tasks in the future)
+	// Lift suspensions whose end time has passed
+	if err := c.EventScheduler.AddJob("lift-expired-suspensions", "* * * * *", func() {
+		lifted, err := c.Suspensions.LiftExpired(context.Background())
+		if err != nil {
+			log.Printf("Warning: Failed to lift expired suspensions: %v", err)
+		} else if lifted > 0 {
+			log.Printf("✓ Lifted %d expired suspension(s)", lifted)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Record the end of impersonation sessions that expired without being ended
+	if err := c.EventScheduler.AddJob("close-expired-impersonations", "* * * * *", func() {
+		closed, err := c.Impersonations.CloseExpired(context.Background())
+		if err != nil {
+			log.Printf("Warning: Failed to close expired impersonation sessions: %v", err)
+		} else if closed > 0 {
+			log.Printf("✓ Closed %d expired impersonation session(s)", closed)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Sign the audit chain head so truncation of recent events is detectable
+	if err := c.EventScheduler.AddJob("audit-checkpoint", c.Config.Audit.CheckpointSchedule, func() {
+		checkpoint, err := c.AuditService.WriteCheckpoint(context.Background())
+		if err != nil {
+			log.Printf("Warning: Failed to write audit checkpoint: %v", err)
+		} else if checkpoint != nil {
+			log.Printf("✓ Audit checkpoint written at sequence %d", checkpoint.Sequence)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Remove outbox events that were published longer ago than the retention period
+	if err := c.EventScheduler.AddJob("delete-published-outbox-events", "0 * * * *", func() {
+		deleted, err := c.OutboxRelay.DeletePublished(context.Background())
+		if err != nil {
+			log.Printf("Warning: Failed to delete published outbox events: %v", err)
+		} else if deleted > 0 {
+			log.Printf("✓ Deleted %d published outbox event(s)", deleted)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Remove webhook deliveries that finished longer ago than the retention period
+	if err := c.EventScheduler.AddJob("delete-finished-webhook-deliveries", "30 * * * *", func() {
+		deleted, err := c.Dispatcher.DeleteFinished(context.Background())
+		if err != nil {
+			log.Printf("Warning: Failed to delete finished webhook deliveries: %v", err)
+		} else if deleted > 0 {
+			log.Printf("✓ Deleted %d finished webhook deliveries", deleted)
+		}
+	}); err != nil {
+		return err
+	}
+
+	// Start the scheduler
 	c.EventScheduler.Start()
 	log.Println("✓ Event scheduler started")
 
+	c.OutboxRelay.Start()
+	log.Println("✓ Outbox relay started")
+
+	c.Dispatcher.Start()
+	log.Println("✓ Webhook dispatcher started")
+
 	return nil
 }
 
@@ -167,6 +391,29 @@ func (c *Container) Cleanup() error {
 		}
 	}
 
+	// Finish queued syncs while the publisher is still open; syncs cut short are dead-lettered
+	if c.SyncWorkers != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), c.Config.Sync.ShutdownTimeout)
+		if err := c.SyncWorkers.Shutdown(ctx); err != nil {
+			log.Printf("Warning: Sync workers did not finish in time: %v", err)
+		} else {
+			log.Println("✓ Sync workers drained")
+		}
+		cancel()
+	}
+
+	// Stop the outbox relay before its publisher goes away; unpublished events stay in the outbox
+	if c.OutboxRelay != nil {
+		c.OutboxRelay.Stop()
+		log.Println("✓ Outbox relay stopped")
+	}
+
+	// Let requests in flight finish; undelivered webhooks stay queued
+	if c.Dispatcher != nil {
+		c.Dispatcher.Stop()
+		log.Println("✓ Webhook dispatcher stopped")
+	}
+
 	// Close NATS Event Publisher
 	if c.EventPublisher != nil {
 		if err := c.EventPublisher.Close(); err != nil {
@@ -211,8 +458,20 @@ func (c *Container) GetConfig() *config.Config {
 
 func (c *Container) GetHandlerServices() *handlers.Services {
 	return &handlers.Services{
-		UserService:  c.UserService,
-		OAuthService: c.OAuthService,
-		Config:       c.Config,
+		UserService:    c.UserService,
+		OAuthService:   c.OAuthService,
+		TokenService:   c.TokenService,
+		RBACService:    c.RBACService,
+		OrgService:     c.OrgService,
+		PATService:     c.PATService,
+		Sessions:       c.Sessions,
+		AdminService:   c.AdminService,
+		Suspensions:    c.Suspensions,
+		Lockouts:       c.Lockouts,
+		Impersonations: c.Impersonations,
+		AuditService:   c.AuditService,
+		DeadLetters:    c.DeadLetters,
+		Webhooks:       c.Webhooks,
+		Config:         c.Config,
 	}
 }
\ No newline at end of file
@@ -30,7 +30,7 @@ var (
 			Name: "events_published_total",
 			Help: "Total number of events published",
 		},
-		[]string{"topic", "status"}, // status: success, failure
+		[]string{"topic", "status"}, // status: success, duplicate, failure
 	)
 
 	EventPublishDuration = promauto.NewHistogramVec(
@@ -66,6 +66,114 @@ var (
 		[]string{"provider", "status"}, // provider: google, facebook, line
 	)
 
+	// Rate Limiting Metrics
+	RateLimitBlockedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_blocked_total",
+			Help: "Total number of requests rejected by the rate limiter",
+		},
+		[]string{"route", "key"}, // key: ip, email, client
+	)
+
+	// Sign-in Risk Metrics
+	LoginRiskDecisionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "login_risk_decisions_total",
+			Help: "Total number of sign-in risk assessments by decision",
+		},
+		[]string{"method", "decision"}, // decision: allow, step_up, deny
+	)
+
+	// Outbox Metrics
+	OutboxEventsRelayedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_events_relayed_total",
+			Help: "Total number of outbox events relayed by outcome",
+		},
+		[]string{"topic", "status"}, // status: success, failure
+	)
+
+	OutboxPendingEvents = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "outbox_pending_events",
+			Help: "Number of outbox events waiting to be published",
+		},
+	)
+
+	// Dead-letter Queue Metrics
+	DeadLetterEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dead_letter_events_total",
+			Help: "Total number of events moved to the dead-letter queue",
+		},
+		[]string{"topic", "source"}, // source: sync, outbox
+	)
+
+	DeadLetterQueueSize = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "dead_letter_queue_size",
+			Help: "Number of undelivered events in the dead-letter queue",
+		},
+	)
+
+	// Background Worker Pool Metrics
+	WorkerPoolJobsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "worker_pool_jobs_total",
+			Help: "Total number of background jobs by outcome",
+		},
+		[]string{"pool", "status"}, // status: success, failure, rejected
+	)
+
+	WorkerPoolJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "worker_pool_job_duration_seconds",
+			Help:    "Background job duration in seconds, including retries",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"pool"},
+	)
+
+	WorkerPoolQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "worker_pool_queue_depth",
+			Help: "Number of background jobs waiting for a worker",
+		},
+		[]string{"pool"},
+	)
+
+	WorkerPoolActiveJobs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "worker_pool_active_jobs",
+			Help: "Number of background jobs currently running",
+		},
+		[]string{"pool"},
+	)
+
+	// Webhook Metrics
+	WebhookDeliveryAttemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_delivery_attempts_total",
+			Help: "Total number of webhook delivery attempts by outcome",
+		},
+		[]string{"status"}, // status: success, failure
+	)
+
+	WebhookDeliveryDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "webhook_delivery_duration_seconds",
+			Help:    "Webhook request duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	WebhookEndpointsDisabledTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "webhook_endpoints_disabled_total",
+			Help: "Total number of webhook endpoints disabled after repeated failures",
+		},
+	)
+
 	// NATS Connection Status
 	NATSConnectionStatus = promauto.NewGauge(
 		prometheus.GaugeOpts{
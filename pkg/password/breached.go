@@ -0,0 +1,74 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BreachedList looks passwords up in a local copy of a breached-password corpus laid out like the
+// Pwned Passwords range API: one file per 5-character SHA-1 prefix (e.g. "5BAA6" or "5BAA6.txt")
+// holding "SUFFIX:COUNT" lines. Files are read on demand, so the corpus never has to fit in memory
+// and the plaintext password never leaves the process.
+type BreachedList struct {
+	dir string
+}
+
+// NewBreachedList opens the prefix-file directory. An empty dir disables the check.
+func NewBreachedList(dir string) (*BreachedList, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.New("breached password list must be a directory of prefix files")
+	}
+	return &BreachedList{dir: dir}, nil
+}
+
+// Contains reports whether the password appears in the corpus
+func (l *BreachedList) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := l.openPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		candidate, _, _ := strings.Cut(line, ":")
+		if strings.EqualFold(candidate, suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func (l *BreachedList) openPrefix(prefix string) (*os.File, error) {
+	for _, name := range []string{prefix, prefix + ".txt", strings.ToLower(prefix), strings.ToLower(prefix) + ".txt"} {
+		file, err := os.Open(filepath.Join(l.dir, name))
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fs.ErrNotExist
+}
@@ -0,0 +1,120 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnknownHashFormat is returned for stored hashes no supported algorithm recognises
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// Argon2Params are the tunable argon2id cost parameters
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Hasher hashes new passwords with the current algorithm and verifies every algorithm still in the
+// database. Hashes are self-describing (PHC / modular crypt format), so the algorithm and its
+// parameters are read from the stored value.
+type Hasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encoded, and whether encoded was produced by an
+	// older algorithm or weaker parameters and should be replaced by Hash(password)
+	Verify(password, encoded string) (match bool, needsRehash bool, err error)
+}
+
+type argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewHasher returns a Hasher that writes argon2id and still accepts legacy bcrypt hashes,
+// including those loaded by the user import tools
+func NewHasher(params Argon2Params) Hasher {
+	return &argon2idHasher{params: params}
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(password, encoded string) (bool, bool, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return h.verifyArgon2id(password, encoded)
+	case isBcrypt(encoded):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, err
+		}
+		return true, true, nil
+	default:
+		return false, false, ErrUnknownHashFormat
+	}
+}
+
+func (h *argon2idHasher) verifyArgon2id(password, encoded string) (bool, bool, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, ErrUnknownHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrUnknownHashFormat
+	}
+
+	var stored Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &stored.Memory, &stored.Iterations, &stored.Parallelism); err != nil {
+		return false, false, ErrUnknownHashFormat
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrUnknownHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, ErrUnknownHashFormat
+	}
+	stored.SaltLength = uint32(len(salt))
+	stored.KeyLength = uint32(len(key))
+
+	candidate := argon2.IDKey([]byte(password), salt, stored.Iterations, stored.Memory, stored.Parallelism, stored.KeyLength)
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return false, false, nil
+	}
+
+	return true, stored != h.params, nil
+}
+
+// isBcrypt recognises the $2a$, $2b$ and $2y$ variants written by other bcrypt implementations
+func isBcrypt(encoded string) bool {
+	return len(encoded) == 60 && (strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$"))
+}
@@ -0,0 +1,155 @@
+package password
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Violation reasons returned to clients
+const (
+	ReasonTooShort           = "too_short"
+	ReasonTooLong            = "too_long"
+	ReasonMissingUpper       = "missing_uppercase"
+	ReasonMissingLower       = "missing_lowercase"
+	ReasonMissingDigit       = "missing_digit"
+	ReasonMissingSymbol      = "missing_symbol"
+	ReasonContainsUserInfo   = "contains_user_info"
+	ReasonContainsBannedWord = "contains_banned_word"
+	ReasonTooWeak            = "too_weak"
+	ReasonBreached           = "breached"
+)
+
+// minUserInputLength keeps short names ("al", "jo") from rejecting half of all passwords
+const minUserInputLength = 4
+
+// Policy is the set of rules a new password must satisfy
+type Policy struct {
+	MinLength     int
+	MaxLength     int // Bytes; bounds hashing cost
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	MinScore      int      // Minimum strength score (0-4), 0 disables the check
+	BannedWords   []string // Case-insensitive substrings that may never appear
+}
+
+// Violation is one reason a password was rejected
+type Violation struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// PolicyError lists every rule a password broke
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return "password does not meet the policy: " + strings.Join(messages, "; ")
+}
+
+// Check returns every policy violation of password. userInputs are values tied to the account
+// (email, username, display name) that the password must not contain.
+func (p *Policy) Check(password string, userInputs ...string) []Violation {
+	var violations []Violation
+	add := func(reason, message string) {
+		violations = append(violations, Violation{Reason: reason, Message: message})
+	}
+
+	length := len([]rune(password))
+	if p.MinLength > 0 && length < p.MinLength {
+		add(ReasonTooShort, "password must be at least "+strconv.Itoa(p.MinLength)+" characters")
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		add(ReasonTooLong, "password must be at most "+strconv.Itoa(p.MaxLength)+" bytes")
+	}
+
+	classes := characterClasses(password)
+	if p.RequireUpper && !classes.upper {
+		add(ReasonMissingUpper, "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !classes.lower {
+		add(ReasonMissingLower, "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !classes.digit {
+		add(ReasonMissingDigit, "password must contain a digit")
+	}
+	if p.RequireSymbol && !classes.symbol {
+		add(ReasonMissingSymbol, "password must contain a symbol")
+	}
+
+	lower := strings.ToLower(password)
+	for _, input := range expandUserInputs(userInputs) {
+		if strings.Contains(lower, input) {
+			add(ReasonContainsUserInfo, "password must not contain your name, username or email")
+			break
+		}
+	}
+	for _, word := range p.BannedWords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" && strings.Contains(lower, word) {
+			add(ReasonContainsBannedWord, "password contains a word that is not allowed")
+			break
+		}
+	}
+
+	if p.MinScore > 0 && Score(password, userInputs...) < p.MinScore {
+		add(ReasonTooWeak, "password is too easy to guess")
+	}
+
+	return violations
+}
+
+type classSet struct {
+	upper, lower, digit, symbol bool
+}
+
+func characterClasses(password string) classSet {
+	var classes classSet
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			classes.upper = true
+		case unicode.IsLower(r):
+			classes.lower = true
+		case unicode.IsDigit(r):
+			classes.digit = true
+		default:
+			classes.symbol = true
+		}
+	}
+	return classes
+}
+
+// expandUserInputs lower-cases the inputs and splits names and email local parts into their words.
+// Email domains are dropped: "gmail" says nothing about the account.
+func expandUserInputs(userInputs []string) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+	add := func(value string) {
+		if len([]rune(value)) >= minUserInputLength && !seen[value] {
+			seen[value] = true
+			expanded = append(expanded, value)
+		}
+	}
+
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if at := strings.LastIndex(input, "@"); at >= 0 {
+			input = input[:at]
+		}
+		add(input)
+		for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			add(part)
+		}
+	}
+	return expanded
+}
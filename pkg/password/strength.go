@@ -0,0 +1,176 @@
+package password
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// commonWords are passwords and fragments that attackers try first. The breached list catches
+// whole leaked passwords; this list catches them as parts of longer ones.
+var commonWords = []string{
+	"password", "passw0rd", "qwerty", "qwertyuiop", "asdfgh", "asdfghjkl", "zxcvbn", "zxcvbnm",
+	"letmein", "welcome", "admin", "administrator", "login", "master", "monkey", "dragon",
+	"football", "baseball", "basketball", "soccer", "hockey", "superman", "batman", "iloveyou",
+	"sunshine", "princess", "shadow", "michael", "charlie", "jordan", "jennifer", "hunter",
+	"trustno1", "starwars", "whatever", "freedom", "secret", "summer", "winter", "spring",
+	"autumn", "hello", "love", "lovely", "qazwsx", "abc123", "123456", "12345678", "123456789",
+	"1234567890", "111111", "000000", "654321", "123123", "666666", "121212", "access", "flower",
+	"cheese", "computer", "internet", "google", "samsung", "apple", "orange", "banana", "pepper",
+	"ginger", "cookie", "chocolate", "killer", "ninja", "mustang", "thomas", "matrix", "pokemon",
+	"change", "changeme", "default", "guest", "root", "test", "user", "pass", "temp", "thailand",
+	"bangkok",
+}
+
+// keyboardRows are used to spot walks such as "qwer" or "asdf"
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+// leetSubstitutions undoes common character swaps before dictionary matching
+var leetSubstitutions = strings.NewReplacer(
+	"@", "a", "4", "a", "8", "b", "3", "e", "6", "g", "1", "l", "!", "i",
+	"0", "o", "$", "s", "5", "s", "7", "t", "+", "t", "2", "z",
+)
+
+// Score rates password strength from 0 (trivial) to 4 (strong), in the spirit of zxcvbn:
+// it estimates the guesses an attacker needs, treating dictionary words, the user's own details,
+// repeats, sequences and keyboard walks as cheap, and maps log10(guesses) onto five buckets.
+func Score(password string, userInputs ...string) int {
+	guesses := estimateGuesses(password, userInputs)
+	switch {
+	case guesses < 1e3:
+		return 0
+	case guesses < 1e6:
+		return 1
+	case guesses < 1e8:
+		return 2
+	case guesses < 1e10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// estimateGuesses adds up the bits each part of the password costs an attacker
+func estimateGuesses(password string, userInputs []string) float64 {
+	runes := []rune(strings.ToLower(password))
+	if len(runes) == 0 {
+		return 1
+	}
+	normalized := []rune(leetSubstitutions.Replace(string(runes)))
+	if len(normalized) != len(runes) {
+		normalized = runes
+	}
+
+	bits := 0.0
+	covered := make([]bool, len(runes))
+
+	// Dictionary matches cost roughly log2 of the dictionary size, user details almost nothing
+	bits += coverMatches(runes, normalized, covered, expandUserInputs(userInputs), 1)
+	bits += coverMatches(runes, normalized, covered, commonWords, math.Log2(float64(len(commonWords)))+1)
+
+	charsetBits := math.Log2(float64(charsetSize(password)))
+	for i, r := range runes {
+		if covered[i] {
+			continue
+		}
+		switch {
+		case i > 0 && runes[i-1] == r:
+			bits += 1 // Repeat
+		case i > 0 && (isSequential(runes[i-1], r) || isKeyboardAdjacent(runes[i-1], r)):
+			bits += 2 // Sequence or keyboard walk
+		default:
+			bits += charsetBits
+		}
+	}
+
+	return math.Pow(2, bits)
+}
+
+// coverMatches marks occurrences of words not yet covered and returns their cost in bits.
+// Longer words are matched first.
+func coverMatches(runes, normalized []rune, covered []bool, words []string, cost float64) float64 {
+	sorted := append([]string(nil), words...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	bits := 0.0
+	for _, word := range sorted {
+		target := []rune(word)
+		if len(target) < 3 {
+			continue
+		}
+		for _, candidate := range [][]rune{runes, normalized} {
+			for start := 0; start+len(target) <= len(candidate); start++ {
+				if !matchesAt(candidate, target, start) || anyCovered(covered, start, len(target)) {
+					continue
+				}
+				for i := start; i < start+len(target); i++ {
+					covered[i] = true
+				}
+				bits += cost
+			}
+		}
+	}
+	return bits
+}
+
+func matchesAt(candidate, target []rune, start int) bool {
+	for i, r := range target {
+		if candidate[start+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func anyCovered(covered []bool, start, length int) bool {
+	for i := start; i < start+length; i++ {
+		if covered[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func charsetSize(password string) int {
+	classes := characterClasses(password)
+	size := 0
+	if classes.lower {
+		size += 26
+	}
+	if classes.upper {
+		size += 26
+	}
+	if classes.digit {
+		size += 10
+	}
+	if classes.symbol {
+		size += 33
+	}
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
+
+func isSequential(prev, r rune) bool {
+	return r == prev+1 || r == prev-1
+}
+
+func isKeyboardAdjacent(prev, r rune) bool {
+	for _, row := range keyboardRows {
+		i := strings.IndexRune(row, prev)
+		if i < 0 {
+			continue
+		}
+		j := strings.IndexRune(row, r)
+		if j >= 0 && (j == i+1 || j == i-1) {
+			return true
+		}
+	}
+	return false
+}
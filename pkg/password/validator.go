@@ -0,0 +1,39 @@
+package password
+
+import "log"
+
+// Validator applies the policy and the breached-password check to new passwords
+type Validator struct {
+	policy   Policy
+	breached *BreachedList
+}
+
+func NewValidator(policy Policy, breached *BreachedList) *Validator {
+	return &Validator{
+		policy:   policy,
+		breached: breached,
+	}
+}
+
+// Validate returns a *PolicyError listing every violation, or nil when the password is acceptable
+func (v *Validator) Validate(password string, userInputs ...string) error {
+	violations := v.policy.Check(password, userInputs...)
+
+	if v.breached != nil {
+		breached, err := v.breached.Contains(password)
+		if err != nil {
+			// The list is a defence in depth; an unreadable file must not block password changes
+			log.Printf("Warning: breached password check failed: %v", err)
+		} else if breached {
+			violations = append(violations, Violation{
+				Reason:  ReasonBreached,
+				Message: "password has appeared in a data breach, choose a different one",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
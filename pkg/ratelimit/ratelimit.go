@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Result describes the state of a rate limit bucket after a request was counted
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the oldest counted request leaves the window
+	Reset time.Duration
+}
+
+// Limiter counts requests per key in a sliding window
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (*Result, error)
+}
+
+// MemoryLimiter is a process-local sliding window log.
+// Limits are per instance, so it is meant as a fallback when Redis is unavailable.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string][]time.Time
+}
+
+// NewMemoryLimiter creates a MemoryLimiter and starts its cleanup goroutine
+func NewMemoryLimiter() *MemoryLimiter {
+	l := &MemoryLimiter{
+		buckets: make(map[string][]time.Time),
+	}
+	go l.cleanup()
+	return l
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*Result, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hits := pruneBefore(l.buckets[key], now.Add(-window))
+
+	result := &Result{Limit: limit}
+	if len(hits) < limit {
+		hits = append(hits, now)
+		result.Allowed = true
+		result.Remaining = limit - len(hits)
+	}
+	result.Reset = hits[0].Add(window).Sub(now)
+	l.buckets[key] = hits
+
+	return result, nil
+}
+
+// cleanup drops buckets whose requests have all left any reasonable window
+func (l *MemoryLimiter) cleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-24 * time.Hour)
+		l.mu.Lock()
+		for key, hits := range l.buckets {
+			if len(hits) == 0 || hits[len(hits)-1].Before(cutoff) {
+				delete(l.buckets, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// pruneBefore drops timestamps older than cutoff; hits are in ascending order
+func pruneBefore(hits []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(cutoff) {
+		i++
+	}
+	return hits[i:]
+}
+
+// fallbackLimiter uses secondary whenever primary fails
+type fallbackLimiter struct {
+	primary   Limiter
+	secondary Limiter
+}
+
+// WithFallback returns a Limiter that degrades to secondary when primary returns an error,
+// so an outage of the shared store does not disable throttling entirely
+func WithFallback(primary, secondary Limiter) Limiter {
+	return &fallbackLimiter{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+func (l *fallbackLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (*Result, error) {
+	result, err := l.primary.Allow(ctx, key, limit, window)
+	if err == nil {
+		return result, nil
+	}
+
+	log.Printf("Warning: rate limiter store unavailable, using in-memory fallback: %v", err)
+	return l.secondary.Allow(ctx, key, limit, window)
+}
@@ -0,0 +1,125 @@
+package risk
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Location is where an address is registered
+type Location struct {
+	Country   string
+	Latitude  float64
+	Longitude float64
+}
+
+type geoRange struct {
+	start, end netip.Addr
+	location   Location
+}
+
+// GeoIP resolves addresses against a local range database, so no lookup leaves the process
+type GeoIP struct {
+	ranges []geoRange
+}
+
+// LoadGeoIP reads a CSV file of "start_ip,end_ip,country_code,latitude,longitude" rows
+// (the layout of the DB-IP and IP2Location lite city exports once trimmed to these columns).
+// Rows that cannot be parsed, such as a header, are skipped. An empty path returns nil.
+func LoadGeoIP(path string) (*GeoIP, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	geo := &GeoIP{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if entry, ok := parseGeoRange(record); ok {
+			geo.ranges = append(geo.ranges, entry)
+		}
+	}
+	if len(geo.ranges) == 0 {
+		return nil, errors.New("GeoIP database has no usable rows")
+	}
+
+	sort.Slice(geo.ranges, func(i, j int) bool {
+		return geo.ranges[i].start.Less(geo.ranges[j].start)
+	})
+	return geo, nil
+}
+
+func parseGeoRange(record []string) (geoRange, bool) {
+	if len(record) < 5 {
+		return geoRange{}, false
+	}
+	start, err1 := netip.ParseAddr(strings.TrimSpace(record[0]))
+	end, err2 := netip.ParseAddr(strings.TrimSpace(record[1]))
+	latitude, err3 := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+	longitude, err4 := strconv.ParseFloat(strings.TrimSpace(record[4]), 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return geoRange{}, false
+	}
+
+	return geoRange{
+		start: start.Unmap(),
+		end:   end.Unmap(),
+		location: Location{
+			Country:   strings.ToUpper(strings.TrimSpace(record[2])),
+			Latitude:  latitude,
+			Longitude: longitude,
+		},
+	}, true
+}
+
+// Lookup returns the location of ip, if the database covers it
+func (g *GeoIP) Lookup(ip string) (*Location, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, false
+	}
+	addr = addr.Unmap()
+
+	// Last range starting at or before addr
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return addr.Less(g.ranges[i].start)
+	}) - 1
+	if i < 0 || g.ranges[i].end.Less(addr) {
+		return nil, false
+	}
+	location := g.ranges[i].location
+	return &location, true
+}
+
+// DistanceKm is the great-circle distance between two locations
+func DistanceKm(a, b *Location) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,68 @@
+package risk
+
+import (
+	"bufio"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// IPList is a set of addresses and CIDR ranges, such as Tor exit nodes or hosting providers
+type IPList struct {
+	addrs    map[netip.Addr]struct{}
+	prefixes []netip.Prefix
+}
+
+// LoadIPList reads one address or CIDR range per line; blank lines and "#" comments are ignored.
+// An empty path returns an empty list.
+func LoadIPList(path string) (*IPList, error) {
+	list := &IPList{addrs: make(map[netip.Addr]struct{})}
+	if path == "" {
+		return list, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(line); err == nil {
+			list.prefixes = append(list.prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(line); err == nil {
+			list.addrs[addr.Unmap()] = struct{}{}
+		}
+	}
+	return list, scanner.Err()
+}
+
+// Contains reports whether ip is listed. Unparseable addresses are never listed.
+func (l *IPList) Contains(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	if _, ok := l.addrs[addr]; ok {
+		return true
+	}
+	for _, prefix := range l.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of entries
+func (l *IPList) Len() int {
+	return len(l.addrs) + len(l.prefixes)
+}
@@ -0,0 +1,95 @@
+// Package risk scores sign-in attempts from independent signals and decides whether to allow
+// them, ask for a second factor or block them. Evaluators are pluggable; the engine only adds
+// up their scores and compares the total against thresholds.
+package risk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Decision is the outcome of an assessment
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"
+	DecisionStepUp Decision = "step_up" // Require a second factor before issuing tokens
+	DecisionDeny   Decision = "deny"
+)
+
+// Input describes one sign-in attempt whose credentials have already been verified
+type Input struct {
+	UserID    uuid.UUID
+	Email     string
+	IPAddress string
+	UserAgent string
+	Method    string // "password" | "oauth"
+	Time      time.Time
+}
+
+// Signal is one reason an attempt looks risky
+type Signal struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Evaluator inspects an attempt and returns the signals it raises (none when it looks normal)
+type Evaluator interface {
+	Name() string
+	Evaluate(ctx context.Context, in *Input) ([]Signal, error)
+}
+
+// Thresholds map the total score to a decision
+type Thresholds struct {
+	StepUp int // Scores at or above require a second factor
+	Deny   int // Scores at or above are blocked
+}
+
+// Assessment is the scored result of an attempt
+type Assessment struct {
+	Score    int      `json:"score"`
+	Decision Decision `json:"decision"`
+	Signals  []Signal `json:"signals"`
+}
+
+// Engine runs every evaluator against an attempt
+type Engine struct {
+	thresholds Thresholds
+	evaluators []Evaluator
+}
+
+func NewEngine(thresholds Thresholds, evaluators ...Evaluator) *Engine {
+	return &Engine{thresholds: thresholds, evaluators: evaluators}
+}
+
+// Assess scores the attempt. Evaluators that fail are skipped and their errors returned
+// alongside the assessment, so an unavailable data source cannot block every sign-in.
+func (e *Engine) Assess(ctx context.Context, in *Input) (*Assessment, error) {
+	assessment := &Assessment{Decision: DecisionAllow, Signals: []Signal{}}
+
+	var errs []error
+	for _, evaluator := range e.evaluators {
+		signals, err := evaluator.Evaluate(ctx, in)
+		if err != nil {
+			errs = append(errs, errors.New(evaluator.Name()+": "+err.Error()))
+			continue
+		}
+		for _, signal := range signals {
+			assessment.Score += signal.Score
+			assessment.Signals = append(assessment.Signals, signal)
+		}
+	}
+
+	switch {
+	case e.thresholds.Deny > 0 && assessment.Score >= e.thresholds.Deny:
+		assessment.Decision = DecisionDeny
+	case e.thresholds.StepUp > 0 && assessment.Score >= e.thresholds.StepUp:
+		assessment.Decision = DecisionStepUp
+	}
+
+	return assessment, errors.Join(errs...)
+}
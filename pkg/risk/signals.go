@@ -0,0 +1,167 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sighting is the most recent known activity of a user
+type Sighting struct {
+	IPAddress string
+	At        time.Time
+}
+
+// History answers questions about a user's earlier sign-ins
+type History interface {
+	SeenIP(ctx context.Context, userID uuid.UUID, ip string) (bool, error)
+	// LastSighting returns nil for a user who has never signed in
+	LastSighting(ctx context.Context, userID uuid.UUID) (*Sighting, error)
+}
+
+// FailureCounter reports recent failed sign-ins for the attempt's account and client IP
+type FailureCounter interface {
+	RecentFailures(ctx context.Context, in *Input) (account int64, ip int64, err error)
+}
+
+// newIP flags addresses the user has never signed in from
+type newIP struct {
+	history History
+	score   int
+}
+
+func NewIPEvaluator(history History, score int) Evaluator {
+	return &newIP{history: history, score: score}
+}
+
+func (e *newIP) Name() string { return "new_ip" }
+
+func (e *newIP) Evaluate(ctx context.Context, in *Input) ([]Signal, error) {
+	if in.IPAddress == "" {
+		return nil, nil
+	}
+	seen, err := e.history.SeenIP(ctx, in.UserID, in.IPAddress)
+	if err != nil || seen {
+		return nil, err
+	}
+
+	// Every address is new on the first sign-in
+	last, err := e.history.LastSighting(ctx, in.UserID)
+	if err != nil || last == nil {
+		return nil, err
+	}
+	return []Signal{{Name: e.Name(), Score: e.score}}, nil
+}
+
+// impossibleTravel flags sign-ins too far from the previous one to have been reached in time
+type impossibleTravel struct {
+	history     History
+	geo         *GeoIP
+	maxSpeedKmh float64
+	score       int
+}
+
+// minTravelKm ignores distances within the precision of IP geolocation
+const minTravelKm = 300
+
+func NewImpossibleTravelEvaluator(history History, geo *GeoIP, maxSpeedKmh float64, score int) Evaluator {
+	return &impossibleTravel{history: history, geo: geo, maxSpeedKmh: maxSpeedKmh, score: score}
+}
+
+func (e *impossibleTravel) Name() string { return "impossible_travel" }
+
+func (e *impossibleTravel) Evaluate(ctx context.Context, in *Input) ([]Signal, error) {
+	if e.geo == nil || in.IPAddress == "" {
+		return nil, nil
+	}
+	last, err := e.history.LastSighting(ctx, in.UserID)
+	if err != nil || last == nil || last.IPAddress == in.IPAddress {
+		return nil, err
+	}
+
+	from, ok := e.geo.Lookup(last.IPAddress)
+	if !ok {
+		return nil, nil
+	}
+	to, ok := e.geo.Lookup(in.IPAddress)
+	if !ok {
+		return nil, nil
+	}
+
+	distance := DistanceKm(from, to)
+	if distance < minTravelKm {
+		return nil, nil
+	}
+	elapsed := in.Time.Sub(last.At)
+	if elapsed < time.Minute {
+		elapsed = time.Minute
+	}
+	if distance/elapsed.Hours() <= e.maxSpeedKmh {
+		return nil, nil
+	}
+
+	return []Signal{{
+		Name:   e.Name(),
+		Score:  e.score,
+		Detail: fmt.Sprintf("%s to %s: %.0f km in %s", from.Country, to.Country, distance, elapsed.Round(time.Minute)),
+	}}, nil
+}
+
+// failureVelocity flags attempts following a burst of failed sign-ins, for the account or the address
+type failureVelocity struct {
+	counter   FailureCounter
+	threshold int64
+	score     int
+}
+
+func NewFailureVelocityEvaluator(counter FailureCounter, threshold int, score int) Evaluator {
+	return &failureVelocity{counter: counter, threshold: int64(threshold), score: score}
+}
+
+func (e *failureVelocity) Name() string { return "failed_attempts" }
+
+func (e *failureVelocity) Evaluate(ctx context.Context, in *Input) ([]Signal, error) {
+	if e.threshold <= 0 {
+		return nil, nil
+	}
+	account, ip, err := e.counter.RecentFailures(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := max(account, ip)
+	if failures < e.threshold {
+		return nil, nil
+	}
+	score := e.score
+	if failures >= 2*e.threshold {
+		score *= 2
+	}
+	return []Signal{{
+		Name:   e.Name(),
+		Score:  score,
+		Detail: fmt.Sprintf("%d account, %d address failures", account, ip),
+	}}, nil
+}
+
+// listedIP flags addresses on a reputation list (Tor exits, hosting providers)
+type listedIP struct {
+	name  string
+	list  *IPList
+	score int
+}
+
+func NewListedIPEvaluator(name string, list *IPList, score int) Evaluator {
+	return &listedIP{name: name, list: list, score: score}
+}
+
+func (e *listedIP) Name() string { return e.name }
+
+func (e *listedIP) Evaluate(ctx context.Context, in *Input) ([]Signal, error) {
+	if e.list == nil || !e.list.Contains(in.IPAddress) {
+		return nil, nil
+	}
+	return []Signal{{Name: e.name, Score: e.score}}, nil
+}
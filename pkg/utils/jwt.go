@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -14,24 +15,112 @@ var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("token has expired")
 	ErrMissingToken = errors.New("missing token")
+	ErrRevokedToken = errors.New("token has been revoked")
+	// ErrRevocationUnavailable means the revocation state could not be read, so the token is not trusted
+	ErrRevocationUnavailable = errors.New("token revocation state is unavailable")
+)
+
+// Token types carried in the token_type claim
+const (
+	TokenTypeAccess        = "access"
+	TokenTypePersonalToken = "personal_access_token" // Opaque token, never a JWT
 )
 
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role,omitempty"`
+	UserID      string      `json:"user_id"`
+	Username    string      `json:"username"`
+	Email       string      `json:"email"`
+	Role        string      `json:"role,omitempty"`
+	TokenType   string      `json:"token_type,omitempty"` // "access" (empty = legacy access token)
+	Scope       string      `json:"scope,omitempty"`      // Space-delimited scopes
+	Roles       []string    `json:"roles,omitempty"`
+	Permissions []string    `json:"permissions"`        // Always present on access tokens; absent on legacy tokens
+	OrgID       string      `json:"org_id,omitempty"`   // Active organization
+	OrgRole     string      `json:"org_role,omitempty"` // Role in the active organization
+	Act         *ActorClaim `json:"act,omitempty"`      // Set on impersonation tokens (RFC 8693 section 4.1)
+	SessionID   string      `json:"sid,omitempty"`      // Sign-in session the token belongs to
+	IssuedAtMs  int64       `json:"iat_ms,omitempty"`   // iat in milliseconds, compared with revocation cutoffs
 	jwt.RegisteredClaims
 }
 
+// IssueTime returns when the token was issued, to the millisecond when iat_ms is present.
+// Tokens without it report the start of their iat second.
+func (c *JWTClaims) IssueTime() time.Time {
+	if c.IssuedAtMs > 0 {
+		return time.UnixMilli(c.IssuedAtMs)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
+}
+
+// ActorClaim identifies the administrator acting on behalf of the token's subject
+type ActorClaim struct {
+	Subject  string `json:"sub"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
 type UserContext struct {
-	ID       uuid.UUID
-	Username string
-	Email    string
-	Role     string
+	ID          uuid.UUID
+	Username    string
+	Email       string
+	Role        string
+	Roles       []string
+	Permissions []string
+	OrgID       uuid.UUID // Active organization (uuid.Nil when none)
+	OrgRole     string
+	TokenID     string    // jti of the presented token
+	IssuedAt    time.Time // Issue time of the presented token (see JWTClaims.IssueTime)
+	ExpiresAt   time.Time // exp of the presented token
+	TokenType   string    // TokenTypeAccess or TokenTypePersonalToken
+	ActorID     uuid.UUID // Impersonating administrator (uuid.Nil unless impersonated)
+	SessionID   uuid.UUID // Sign-in session (uuid.Nil for personal access, impersonation and legacy tokens)
 }
 
-func ValidateTokenStringToUUID(tokenString, jwtSecret string) (*UserContext, error) {
+// HasRole reports whether the user holds the role (primary or assigned)
+func (u *UserContext) HasRole(role string) bool {
+	if u.Role == role {
+		return true
+	}
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPersonalAccessToken reports whether the request was authenticated with a personal access token
+func (u *UserContext) IsPersonalAccessToken() bool {
+	return u.TokenType == TokenTypePersonalToken
+}
+
+// IsImpersonated reports whether an administrator is acting as this user.
+// For impersonation tokens TokenID is also the impersonation session ID.
+func (u *UserContext) IsImpersonated() bool {
+	return u.ActorID != uuid.Nil
+}
+
+// HasPermission reports whether the user has been granted the permission
+func (u *UserContext) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateToken signs the given claims with HS256
+func GenerateToken(claims *JWTClaims, jwtSecret string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(jwtSecret))
+}
+
+// ParseToken verifies the signature and expiry of a token and returns its claims
+func ParseToken(tokenString, jwtSecret string) (*JWTClaims, error) {
 	if tokenString == "" {
 		return nil, ErrMissingToken
 	}
@@ -60,17 +149,77 @@ func ValidateTokenStringToUUID(tokenString, jwtSecret string) (*UserContext, err
 		return nil, ErrInvalidToken
 	}
 
+	return claims, nil
+}
+
+// IsAccessToken reports whether the claims belong to an access token. Tokens of any other
+// type (such as refresh tokens signed by earlier releases) must not be used as bearer credentials.
+func (c *JWTClaims) IsAccessToken() bool {
+	return c.TokenType == "" || c.TokenType == TokenTypeAccess
+}
+
+// ValidateTokenStringToUUID validates an access token and returns the user context
+func ValidateTokenStringToUUID(tokenString, jwtSecret string) (*UserContext, error) {
+	claims, err := ParseToken(tokenString, jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.IsAccessToken() {
+		return nil, ErrInvalidToken
+	}
+
+	return ClaimsToUserContext(claims)
+}
+
+// ClaimsToUserContext converts verified claims into a UserContext
+func ClaimsToUserContext(claims *JWTClaims) (*UserContext, error) {
 	userID, err := uuid.Parse(claims.UserID)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
-	return &UserContext{
-		ID:       userID,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Role:     claims.Role,
-	}, nil
+	userCtx := &UserContext{
+		ID:          userID,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		OrgRole:     claims.OrgRole,
+		TokenID:     claims.ID,
+		TokenType:   claims.TokenType,
+	}
+	if userCtx.TokenType == "" {
+		userCtx.TokenType = TokenTypeAccess
+	}
+	if claims.OrgID != "" {
+		orgID, err := uuid.Parse(claims.OrgID)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		userCtx.OrgID = orgID
+	}
+	if claims.Act != nil {
+		actorID, err := uuid.Parse(claims.Act.Subject)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		userCtx.ActorID = actorID
+	}
+	if claims.SessionID != "" {
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+		userCtx.SessionID = sessionID
+	}
+	userCtx.IssuedAt = claims.IssueTime()
+	if claims.ExpiresAt != nil {
+		userCtx.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return userCtx, nil
 }
 
 func ExtractTokenFromHeader(authHeader string) string {
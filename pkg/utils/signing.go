@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// ParseEd25519Seed decodes a base64 32-byte Ed25519 seed into a private key
+func ParseEd25519Seed(encoded string) (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("signing key is not valid base64")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.New("signing key must be a 32-byte Ed25519 seed")
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParseEd25519PublicKey decodes a base64 Ed25519 public key
+func ParseEd25519PublicKey(encoded string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("public key is not valid base64")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("public key must be 32 bytes")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// KeyID returns a short identifier for a public key so rotated keys can be told apart
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+// PersonalAccessTokenPrefix marks opaque personal access tokens so they can be
+// told apart from JWTs and picked up by secret scanners
+const PersonalAccessTokenPrefix = "pat_"
+
+// GenerateRandomToken returns a URL-safe random token with n bytes of entropy
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GenerateNumericCode returns a uniformly random one-time code of the given number of digits
+func GenerateNumericCode(digits int) (string, error) {
+	code := make([]byte, digits)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
+
+// HashToken returns the hex SHA-256 digest used to store opaque tokens at rest
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPersonalAccessToken reports whether the bearer token is a personal access token
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
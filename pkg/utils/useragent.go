@@ -0,0 +1,57 @@
+package utils
+
+import "strings"
+
+// DeviceName summarises a user agent as "<browser> on <platform>" for session lists.
+// It only needs to be recognisable to the user, not exact.
+func DeviceName(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := firstMatch(userAgent, []string{
+		"Edg/", "Microsoft Edge",
+		"OPR/", "Opera",
+		"SamsungBrowser/", "Samsung Internet",
+		"Line/", "LINE",
+		"FBAN", "Facebook",
+		"Firefox/", "Firefox",
+		"CriOS/", "Chrome",
+		"Chrome/", "Chrome",
+		"Safari/", "Safari",
+		"okhttp", "Android app",
+		"Dart/", "Mobile app",
+		"curl/", "curl",
+	})
+	platform := firstMatch(userAgent, []string{
+		"iPhone", "iPhone",
+		"iPad", "iPad",
+		"Android", "Android",
+		"Windows", "Windows",
+		"Mac OS X", "macOS",
+		"Macintosh", "macOS",
+		"CrOS", "ChromeOS",
+		"Linux", "Linux",
+	})
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
+
+// firstMatch returns the label of the first marker (pairs of marker, label) found in s
+func firstMatch(s string, pairs []string) string {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if strings.Contains(s, pairs[i]) {
+			return pairs[i+1]
+		}
+	}
+	return ""
+}
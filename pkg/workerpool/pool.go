@@ -0,0 +1,123 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"gofiber-template/pkg/metrics"
+)
+
+// ErrClosed is returned by Submit after Shutdown has started
+var ErrClosed = errors.New("worker pool is shut down")
+
+// ErrQueueFull is returned by Submit when every queue slot is taken
+var ErrQueueFull = errors.New("worker pool queue is full")
+
+// Job is a unit of background work. ctx carries no request state and is cancelled
+// only when shutdown runs out of time, so jobs should return promptly once it is done.
+type Job func(ctx context.Context) error
+
+// Pool runs jobs on a fixed number of goroutines fed by a bounded queue.
+// It replaces ad-hoc goroutines so that background work is limited, observable
+// and finished (or cancelled) on shutdown.
+type Pool struct {
+	name   string
+	jobs   chan Job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// New starts a pool with the given number of workers and queue capacity
+func New(name string, workers, queueSize int) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		name:   name,
+		jobs:   make(chan Job, max(queueSize, 0)),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for i := 0; i < max(workers, 1); i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues a job without blocking
+func (p *Pool) Submit(job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		metrics.WorkerPoolJobsTotal.WithLabelValues(p.name, "rejected").Inc()
+		return ErrClosed
+	}
+
+	select {
+	case p.jobs <- job:
+		metrics.WorkerPoolQueueDepth.WithLabelValues(p.name).Inc()
+		return nil
+	default:
+		metrics.WorkerPoolJobsTotal.WithLabelValues(p.name, "rejected").Inc()
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting jobs and waits until the queued ones have run. When ctx ends
+// first, the context of running jobs is cancelled and Shutdown returns ctx's error once
+// the workers have exited; jobs still queued at that point run with a cancelled context.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		metrics.WorkerPoolQueueDepth.WithLabelValues(p.name).Dec()
+		p.run(job)
+	}
+}
+
+func (p *Pool) run(job Job) {
+	metrics.WorkerPoolActiveJobs.WithLabelValues(p.name).Inc()
+	defer metrics.WorkerPoolActiveJobs.WithLabelValues(p.name).Dec()
+
+	start := time.Now()
+	err := job(p.ctx)
+	metrics.WorkerPoolJobDuration.WithLabelValues(p.name).Observe(time.Since(start).Seconds())
+
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	metrics.WorkerPoolJobsTotal.WithLabelValues(p.name, status).Inc()
+}